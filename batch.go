@@ -0,0 +1,121 @@
+package hord
+
+import "errors"
+
+// BatchDatabase is an optional interface implemented by drivers that support operating on multiple keys in a single
+// round trip. Use the GetMany, SetMany, and DeleteMany functions to perform batch operations against any Database;
+// they will use the native implementation when available and fall back to single key operations otherwise.
+type BatchDatabase interface {
+	Database
+
+	// GetMany is used to fetch data for multiple keys. The returned map contains an entry for each key found, keys
+	// that do not exist are omitted from the results rather than returning ErrNil.
+	GetMany(keys []string) (map[string][]byte, error)
+
+	// SetMany is used to insert and update multiple keys.
+	SetMany(items map[string][]byte) error
+
+	// DeleteMany will delete the data for multiple keys.
+	DeleteMany(keys []string) error
+}
+
+// ValidKeys checks if every key within the provided list is valid.
+// Returns nil if all keys are valid, otherwise returns ErrInvalidKey.
+func ValidKeys(keys []string) error {
+	for _, k := range keys {
+		if err := ValidKey(k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidItems checks if every key and value within the provided map is valid.
+// Returns nil if all items are valid, otherwise returns ErrInvalidKey or ErrInvalidData.
+func ValidItems(items map[string][]byte) error {
+	for k, v := range items {
+		if err := ValidKey(k); err != nil {
+			return err
+		}
+		if err := ValidData(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetMany fetches data for multiple keys from the provided Database. If the Database implements BatchDatabase, the
+// native implementation is used; otherwise, each key is fetched individually. Keys that do not exist are omitted
+// from the results.
+func GetMany(db Database, keys []string) (map[string][]byte, error) {
+	if db == nil {
+		return nil, ErrInvalidDatabase
+	}
+
+	if bdb, ok := db.(BatchDatabase); ok {
+		return bdb.GetMany(keys)
+	}
+
+	if err := ValidKeys(keys); err != nil {
+		return nil, err
+	}
+
+	results := make(map[string][]byte, len(keys))
+	for _, k := range keys {
+		data, err := db.Get(k)
+		if err != nil {
+			if errors.Is(err, ErrNil) {
+				continue
+			}
+			return nil, err
+		}
+		results[k] = data
+	}
+	return results, nil
+}
+
+// SetMany inserts and updates multiple keys within the provided Database. If the Database implements BatchDatabase,
+// the native implementation is used; otherwise, each key is set individually, stopping at the first error.
+func SetMany(db Database, items map[string][]byte) error {
+	if db == nil {
+		return ErrInvalidDatabase
+	}
+
+	if bdb, ok := db.(BatchDatabase); ok {
+		return bdb.SetMany(items)
+	}
+
+	if err := ValidItems(items); err != nil {
+		return err
+	}
+
+	for k, v := range items {
+		if err := db.Set(k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteMany deletes multiple keys from the provided Database. If the Database implements BatchDatabase, the native
+// implementation is used; otherwise, each key is deleted individually, stopping at the first error.
+func DeleteMany(db Database, keys []string) error {
+	if db == nil {
+		return ErrInvalidDatabase
+	}
+
+	if bdb, ok := db.(BatchDatabase); ok {
+		return bdb.DeleteMany(keys)
+	}
+
+	if err := ValidKeys(keys); err != nil {
+		return err
+	}
+
+	for _, k := range keys {
+		if err := db.Delete(k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
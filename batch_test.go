@@ -0,0 +1,102 @@
+package hord
+
+import (
+	"errors"
+	"testing"
+)
+
+// mapDatabase is a simple map backed Database used to validate the batch fallback helpers.
+type mapDatabase struct {
+	data map[string][]byte
+}
+
+func (m *mapDatabase) Setup() error       { return nil }
+func (m *mapDatabase) HealthCheck() error { return nil }
+func (m *mapDatabase) Get(key string) ([]byte, error) {
+	if v, ok := m.data[key]; ok {
+		return v, nil
+	}
+	return nil, ErrNil
+}
+func (m *mapDatabase) Set(key string, data []byte) error { m.data[key] = data; return nil }
+func (m *mapDatabase) Delete(key string) error           { delete(m.data, key); return nil }
+func (m *mapDatabase) Keys() ([]string, error) {
+	var keys []string
+	for k := range m.data {
+		keys = append(keys, k)
+	}
+	return keys, nil
+}
+func (m *mapDatabase) Close() {}
+
+func TestBatchFallback(t *testing.T) {
+	db := &mapDatabase{data: make(map[string][]byte)}
+
+	t.Run("Nil Database", func(t *testing.T) {
+		if _, err := GetMany(nil, []string{"a"}); err != ErrInvalidDatabase {
+			t.Errorf("GetMany returned error: %s, expected ErrInvalidDatabase", err)
+		}
+		if err := SetMany(nil, map[string][]byte{"a": []byte("a")}); err != ErrInvalidDatabase {
+			t.Errorf("SetMany returned error: %s, expected ErrInvalidDatabase", err)
+		}
+		if err := DeleteMany(nil, []string{"a"}); err != ErrInvalidDatabase {
+			t.Errorf("DeleteMany returned error: %s, expected ErrInvalidDatabase", err)
+		}
+	})
+
+	t.Run("Invalid Input", func(t *testing.T) {
+		if _, err := GetMany(db, []string{"a", ""}); err != ErrInvalidKey {
+			t.Errorf("GetMany returned error: %s, expected ErrInvalidKey", err)
+		}
+		if err := SetMany(db, map[string][]byte{"a": nil}); err != ErrInvalidData {
+			t.Errorf("SetMany returned error: %s, expected ErrInvalidData", err)
+		}
+		if err := DeleteMany(db, []string{""}); err != ErrInvalidKey {
+			t.Errorf("DeleteMany returned error: %s, expected ErrInvalidKey", err)
+		}
+	})
+
+	t.Run("Happy Path", func(t *testing.T) {
+		err := SetMany(db, map[string][]byte{"a": []byte("1"), "b": []byte("2")})
+		if err != nil {
+			t.Fatalf("SetMany returned error: %s", err)
+		}
+
+		results, err := GetMany(db, []string{"a", "b", "missing"})
+		if err != nil {
+			t.Fatalf("GetMany returned error: %s", err)
+		}
+		if len(results) != 2 || string(results["a"]) != "1" || string(results["b"]) != "2" {
+			t.Errorf("GetMany returned unexpected results: %v", results)
+		}
+
+		err = DeleteMany(db, []string{"a", "b"})
+		if err != nil {
+			t.Fatalf("DeleteMany returned error: %s", err)
+		}
+		if len(db.data) != 0 {
+			t.Errorf("DeleteMany left keys behind: %v", db.data)
+		}
+	})
+
+	t.Run("Get Error", func(t *testing.T) {
+		_, err := GetMany(WrapContext(&errDatabase{}), []string{"a"})
+		if !errors.Is(err, errTest) {
+			t.Errorf("GetMany returned error: %s, expected %s", err, errTest)
+		}
+	})
+}
+
+// errTest is returned by errDatabase for every operation.
+var errTest = errors.New("test error")
+
+// errDatabase is a Database that fails every operation.
+type errDatabase struct{}
+
+func (e *errDatabase) Setup() error                 { return errTest }
+func (e *errDatabase) HealthCheck() error           { return errTest }
+func (e *errDatabase) Get(_ string) ([]byte, error) { return nil, errTest }
+func (e *errDatabase) Set(_ string, _ []byte) error { return errTest }
+func (e *errDatabase) Delete(_ string) error        { return errTest }
+func (e *errDatabase) Keys() ([]string, error)      { return nil, errTest }
+func (e *errDatabase) Close()                       {}
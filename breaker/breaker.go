@@ -0,0 +1,314 @@
+/*
+Package breaker provides a Hord database wrapper implementing the circuit breaker pattern. When the failure rate of a
+database exceeds a configured threshold, the circuit opens and operations fail fast with hord.ErrUnavailable rather
+than waiting on a degraded backend. After a cool-down period, the database is probed with HealthCheck() and the circuit
+closes once the database is healthy. To use this package, import it as follows:
+
+	import (
+	    "github.com/madflojo/hord"
+	    "github.com/madflojo/hord/breaker"
+	)
+
+# Wrapping a Database
+
+Use the Dial() function to wrap an existing database with a circuit breaker.
+
+	// Handle database connection
+	var database hord.Database
+	...
+
+	db, err := breaker.Dial(breaker.Config{
+		Database:     database,
+		FailureRatio: 0.5,
+		MinRequests:  20,
+		OpenTimeout:  10 * time.Second,
+	})
+	if err != nil {
+	    // Handle connection error
+	}
+
+	// Check the state of the circuit
+	if db.State() == breaker.Open {
+	    // Database is unavailable
+	}
+
+# Caching
+
+When used with the cache/lookaside package, wrap only the backing database. Reads for cached keys continue to be
+served from the cache while the database circuit is open, and cache misses fail fast.
+
+	database, err := breaker.Dial(breaker.Config{Database: cassandraDB})
+	if err != nil {
+	    // Handle connection error
+	}
+
+	db, err := lookaside.Dial(lookaside.Config{
+		Database: database,
+		Cache:    redisDB,
+	})
+*/
+package breaker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/madflojo/hord"
+)
+
+const (
+	// DefaultFailureRatio is the failure ratio used when Config.FailureRatio is not set.
+	DefaultFailureRatio = 0.5
+
+	// DefaultMinRequests is the minimum number of requests used when Config.MinRequests is not set.
+	DefaultMinRequests = 10
+
+	// DefaultWindow is the measurement window used when Config.Window is not set.
+	DefaultWindow = 10 * time.Second
+
+	// DefaultOpenTimeout is the open duration used when Config.OpenTimeout is not set.
+	DefaultOpenTimeout = 30 * time.Second
+
+	// DefaultProbeTimeout is the HealthCheck timeout used when Config.ProbeTimeout is not set.
+	DefaultProbeTimeout = 5 * time.Second
+)
+
+// ErrOpen is returned for operations rejected while the circuit is open. ErrOpen wraps hord.ErrUnavailable.
+var ErrOpen = fmt.Errorf("%w: circuit breaker is open", hord.ErrUnavailable)
+
+// State is the state of the circuit.
+type State int
+
+const (
+	// Closed allows operations to pass through to the database.
+	Closed State = iota
+
+	// Open rejects operations with ErrOpen.
+	Open
+
+	// HalfOpen indicates the database is being probed with HealthCheck. Operations are rejected until the probe
+	// completes.
+	HalfOpen
+)
+
+// String returns a human readable name for the State.
+func (s State) String() string {
+	switch s {
+	case Closed:
+		return "closed"
+	case Open:
+		return "open"
+	case HalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// Config provides the configuration options for the circuit breaker. Zero values are replaced with defaults.
+type Config struct {
+	// Database is the database being protected.
+	Database hord.Database
+
+	// FailureRatio is the ratio of failed operations, between 0 and 1, within a Window that opens the circuit.
+	// Defaults to DefaultFailureRatio.
+	FailureRatio float64
+
+	// MinRequests is the minimum number of operations within a Window before the circuit can open. Defaults to
+	// DefaultMinRequests.
+	MinRequests int
+
+	// Window is the period failures are measured over. Counts are reset at the start of each Window. Defaults to
+	// DefaultWindow.
+	Window time.Duration
+
+	// OpenTimeout is how long the circuit stays open before the database is probed with HealthCheck. Defaults to
+	// DefaultOpenTimeout.
+	OpenTimeout time.Duration
+
+	// ProbeTimeout bounds the HealthCheck used to probe the database once OpenTimeout has elapsed. The probe does not
+	// use the context of the operation that triggered it. Defaults to DefaultProbeTimeout.
+	ProbeTimeout time.Duration
+
+	// Classifier decides if an error counts as a failure. Defaults to Failure.
+	Classifier func(err error) bool
+
+	// OnStateChange is called whenever the circuit changes state. OnStateChange is called while the Breaker is locked
+	// and must not call methods on the Breaker.
+	OnStateChange func(from, to State)
+}
+
+// Failure returns true for errors that indicate the database is degraded, hord.ErrTimeout and hord.ErrUnavailable.
+func Failure(err error) bool {
+	return errors.Is(err, hord.ErrTimeout) || errors.Is(err, hord.ErrUnavailable)
+}
+
+// Breaker wraps a hord.Database with a circuit breaker. It also satisfies the Hord database interface.
+type Breaker struct {
+	hord.ContextDatabase
+
+	// mu protects the circuit state
+	mu sync.Mutex
+
+	// cfg is the circuit breaker configuration
+	cfg Config
+
+	// state is the current state of the circuit
+	state State
+
+	// openedAt is the time the circuit last opened
+	openedAt time.Time
+
+	// windowStart is the start of the current measurement window
+	windowStart time.Time
+
+	// successes is the number of successful operations within the current window
+	successes int
+
+	// failures is the number of failed operations within the current window
+	failures int
+}
+
+// Dial will wrap the Database within the provided Config with a circuit breaker. It will return an error if the
+// Database is nil.
+func Dial(cfg Config) (*Breaker, error) {
+	if cfg.Database == nil {
+		return nil, hord.ErrInvalidDatabase
+	}
+
+	if cfg.FailureRatio <= 0 || cfg.FailureRatio > 1 {
+		cfg.FailureRatio = DefaultFailureRatio
+	}
+	if cfg.MinRequests <= 0 {
+		cfg.MinRequests = DefaultMinRequests
+	}
+	if cfg.Window <= 0 {
+		cfg.Window = DefaultWindow
+	}
+	if cfg.OpenTimeout <= 0 {
+		cfg.OpenTimeout = DefaultOpenTimeout
+	}
+	if cfg.ProbeTimeout <= 0 {
+		cfg.ProbeTimeout = DefaultProbeTimeout
+	}
+	if cfg.Classifier == nil {
+		cfg.Classifier = Failure
+	}
+
+	b := &Breaker{cfg: cfg, windowStart: time.Now()}
+	b.ContextDatabase = hord.Wrap(cfg.Database, b.intercept).(hord.ContextDatabase)
+	return b, nil
+}
+
+// State returns the current state of the circuit.
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// SetWithTTL will write the key with an expiration if the circuit is closed. If the wrapped database does not support
+// TTLs, hord.ErrNotSupported is returned.
+func (b *Breaker) SetWithTTL(key string, data []byte, ttl time.Duration) error {
+	return b.ContextDatabase.(hord.TTLDatabase).SetWithTTL(key, data, ttl)
+}
+
+// Capabilities reports Context support along with the TTL support of the wrapped database.
+func (b *Breaker) Capabilities() hord.Capabilities {
+	return hord.CapabilitiesOf(b.ContextDatabase)
+}
+
+// intercept rejects operations while the circuit is open and records the result of operations that pass through.
+func (b *Breaker) intercept(ctx context.Context, call hord.Call, next hord.Handler) (hord.Result, error) {
+	if err := b.allow(); err != nil {
+		return hord.Result{}, err
+	}
+
+	r, err := next(ctx, call)
+	b.record(err)
+	return r, err
+}
+
+// allow returns ErrOpen if the circuit is open. Once OpenTimeout has elapsed, the calling operation probes the
+// database with HealthCheck, closing the circuit if the probe succeeds. The probe is bounded by ProbeTimeout rather
+// than the caller's context, so a canceled caller cannot re-open a healthy circuit.
+func (b *Breaker) allow() error {
+	b.mu.Lock()
+	if b.state == Closed {
+		b.mu.Unlock()
+		return nil
+	}
+	if b.state == HalfOpen || time.Since(b.openedAt) < b.cfg.OpenTimeout {
+		b.mu.Unlock()
+		return ErrOpen
+	}
+	b.setState(HalfOpen)
+	b.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), b.cfg.ProbeTimeout)
+	err := hord.WrapContext(b.cfg.Database).HealthCheckContext(ctx)
+	cancel()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err != nil {
+		b.setState(Open)
+		return ErrOpen
+	}
+	b.setState(Closed)
+	return nil
+}
+
+// record counts the result of an operation, opening the circuit once the failure ratio is exceeded.
+func (b *Breaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state != Closed {
+		return
+	}
+
+	if time.Since(b.windowStart) >= b.cfg.Window {
+		b.reset()
+	}
+
+	if err != nil && b.cfg.Classifier(err) {
+		b.failures++
+	} else {
+		b.successes++
+	}
+
+	total := b.successes + b.failures
+	if total >= b.cfg.MinRequests && float64(b.failures)/float64(total) >= b.cfg.FailureRatio {
+		b.setState(Open)
+	}
+}
+
+// setState transitions the circuit to the provided State. setState must be called while locked.
+func (b *Breaker) setState(s State) {
+	if b.state == s {
+		return
+	}
+
+	from := b.state
+	b.state = s
+	switch s {
+	case Open:
+		b.openedAt = time.Now()
+	case Closed:
+		b.reset()
+	}
+
+	if b.cfg.OnStateChange != nil {
+		b.cfg.OnStateChange(from, s)
+	}
+}
+
+// reset starts a new measurement window.
+func (b *Breaker) reset() {
+	b.windowStart = time.Now()
+	b.successes = 0
+	b.failures = 0
+}
@@ -0,0 +1,237 @@
+package breaker
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/madflojo/hord"
+	"github.com/madflojo/hord/cache/lookaside"
+	"github.com/madflojo/hord/drivers/mock"
+)
+
+// backend is a mock database whose health is controlled by the test.
+type backend struct {
+	healthy int32
+	calls   int32
+}
+
+func (b *backend) dial(t *testing.T) hord.Database {
+	check := func() error {
+		atomic.AddInt32(&b.calls, 1)
+		if atomic.LoadInt32(&b.healthy) == 0 {
+			return hord.ErrUnavailable
+		}
+		return nil
+	}
+
+	db, err := mock.Dial(mock.Config{
+		HealthCheckFunc: check,
+		GetFunc: func(_ string) ([]byte, error) {
+			if err := check(); err != nil {
+				return nil, err
+			}
+			return []byte("database"), nil
+		},
+		SetFunc: func(_ string, _ []byte) error {
+			return check()
+		},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error creating mock database - %s", err)
+	}
+	return db
+}
+
+func TestDial(t *testing.T) {
+	_, err := Dial(Config{})
+	if err != hord.ErrInvalidDatabase {
+		t.Errorf("Expected ErrInvalidDatabase, got %v", err)
+	}
+
+	if !errors.Is(ErrOpen, hord.ErrUnavailable) {
+		t.Errorf("Expected ErrOpen to wrap ErrUnavailable")
+	}
+}
+
+func TestBreaker(t *testing.T) {
+	be := &backend{}
+	var changes []string
+	db, err := Dial(Config{
+		Database:     be.dial(t),
+		FailureRatio: 0.5,
+		MinRequests:  4,
+		OpenTimeout:  20 * time.Millisecond,
+		OnStateChange: func(from, to State) {
+			changes = append(changes, from.String()+"->"+to.String())
+		},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error creating breaker - %s", err)
+	}
+	defer db.Close()
+
+	t.Run("Closed", func(t *testing.T) {
+		atomic.StoreInt32(&be.healthy, 1)
+		for i := 0; i < 3; i++ {
+			if _, err := db.Get("key"); err != nil {
+				t.Fatalf("Unexpected error - %s", err)
+			}
+		}
+		if db.State() != Closed {
+			t.Errorf("Expected closed circuit, got %s", db.State())
+		}
+	})
+
+	t.Run("Trips", func(t *testing.T) {
+		atomic.StoreInt32(&be.healthy, 0)
+		for i := 0; i < 3; i++ {
+			if err := db.Set("key", []byte("data")); !errors.Is(err, hord.ErrUnavailable) {
+				t.Fatalf("Expected ErrUnavailable, got %v", err)
+			}
+		}
+		if db.State() != Open {
+			t.Fatalf("Expected open circuit, got %s", db.State())
+		}
+
+		calls := atomic.LoadInt32(&be.calls)
+		if _, err := db.Get("key"); err != ErrOpen {
+			t.Errorf("Expected ErrOpen, got %v", err)
+		}
+		if atomic.LoadInt32(&be.calls) != calls {
+			t.Errorf("Expected database not to be called while circuit is open")
+		}
+	})
+
+	t.Run("Failed Probe", func(t *testing.T) {
+		time.Sleep(30 * time.Millisecond)
+		if _, err := db.Get("key"); err != ErrOpen {
+			t.Errorf("Expected ErrOpen, got %v", err)
+		}
+		if db.State() != Open {
+			t.Errorf("Expected open circuit, got %s", db.State())
+		}
+	})
+
+	t.Run("Recovers", func(t *testing.T) {
+		atomic.StoreInt32(&be.healthy, 1)
+		time.Sleep(30 * time.Millisecond)
+		if _, err := db.Get("key"); err != nil {
+			t.Errorf("Unexpected error - %s", err)
+		}
+		if db.State() != Closed {
+			t.Errorf("Expected closed circuit, got %s", db.State())
+		}
+	})
+
+	expected := []string{"closed->open", "open->half-open", "half-open->open", "open->half-open", "half-open->closed"}
+	if len(changes) != len(expected) {
+		t.Fatalf("Unexpected state changes %v, expected %v", changes, expected)
+	}
+	for i := range expected {
+		if changes[i] != expected[i] {
+			t.Errorf("Unexpected state changes %v, expected %v", changes, expected)
+		}
+	}
+}
+
+func TestProbeCanceledCaller(t *testing.T) {
+	be := &backend{}
+	db, err := Dial(Config{Database: be.dial(t), MinRequests: 1, OpenTimeout: 20 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("Unexpected error creating breaker - %s", err)
+	}
+	defer db.Close()
+
+	if err := db.Set("key", []byte("data")); !errors.Is(err, hord.ErrUnavailable) {
+		t.Fatalf("Expected ErrUnavailable, got %v", err)
+	}
+	if db.State() != Open {
+		t.Fatalf("Expected open circuit, got %s", db.State())
+	}
+
+	// A caller whose context is already canceled runs the probe against a healthy database
+	atomic.StoreInt32(&be.healthy, 1)
+	time.Sleep(30 * time.Millisecond)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := db.GetContext(ctx, "key"); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if db.State() != Closed {
+		t.Errorf("Expected closed circuit after probing a healthy database, got %s", db.State())
+	}
+}
+
+func TestClassifier(t *testing.T) {
+	db, err := Dial(Config{
+		Database: func() hord.Database {
+			db, _ := mock.Dial(mock.Config{GetFunc: func(_ string) ([]byte, error) { return nil, hord.ErrNil }})
+			return db
+		}(),
+		MinRequests: 1,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error creating breaker - %s", err)
+	}
+
+	for i := 0; i < 5; i++ {
+		if _, err := db.Get("key"); err != hord.ErrNil {
+			t.Fatalf("Expected ErrNil, got %v", err)
+		}
+	}
+	if db.State() != Closed {
+		t.Errorf("Expected ErrNil not to count as a failure")
+	}
+
+	if c := db.Capabilities(); !c.Context || !c.TTL {
+		t.Errorf("Unexpected capabilities %+v", c)
+	}
+	if err := db.SetWithTTL("key", []byte("data"), time.Minute); err != nil {
+		t.Errorf("Unexpected error - %s", err)
+	}
+}
+
+func TestLookaside(t *testing.T) {
+	be := &backend{}
+	database, err := Dial(Config{Database: be.dial(t), MinRequests: 1, OpenTimeout: time.Minute})
+	if err != nil {
+		t.Fatalf("Unexpected error creating breaker - %s", err)
+	}
+
+	cache, err := mock.Dial(mock.Config{
+		GetFunc: func(key string) ([]byte, error) {
+			if key == "cached" {
+				return []byte("cache"), nil
+			}
+			return nil, hord.ErrNil
+		},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error creating mock cache - %s", err)
+	}
+
+	db, err := lookaside.Dial(lookaside.Config{Database: database, Cache: cache})
+	if err != nil {
+		t.Fatalf("Unexpected error creating lookaside cache - %s", err)
+	}
+
+	// Trip the circuit
+	if _, err := db.Get("missing"); !errors.Is(err, hord.ErrUnavailable) {
+		t.Fatalf("Expected ErrUnavailable, got %v", err)
+	}
+	if database.State() != Open {
+		t.Fatalf("Expected open circuit, got %s", database.State())
+	}
+
+	data, err := db.Get("cached")
+	if err != nil || string(data) != "cache" {
+		t.Errorf("Expected cached read while circuit is open, got %q - %v", data, err)
+	}
+
+	if _, err := db.Get("missing"); err != ErrOpen {
+		t.Errorf("Expected ErrOpen for cache miss, got %v", err)
+	}
+}
@@ -85,18 +85,26 @@ Hord provides a simple abstraction for working with the cache, with easy-to-use
 package cache
 
 import (
+	"context"
 	"errors"
+	"time"
 
 	"github.com/madflojo/hord"
 	"github.com/madflojo/hord/cache/lookaside"
+	"github.com/madflojo/hord/cache/refreshahead"
+	"github.com/madflojo/hord/cache/writebehind"
+	"github.com/madflojo/hord/cache/writethrough"
 )
 
 // CacheType is the type of cache to use.
 type Type string
 
 const (
-	Lookaside Type = "lookaside"
-	None      Type = "none"
+	Lookaside    Type = "lookaside"
+	WriteThrough Type = "writethrough"
+	WriteBehind  Type = "writebehind"
+	RefreshAhead Type = "refreshahead"
+	None         Type = "none"
 )
 
 // Config provides the configuration options for the Cache driver.
@@ -104,6 +112,19 @@ type Config struct {
 	Type     Type
 	Database hord.Database
 	Cache    hord.Database
+
+	// Coalesce and FillTimeout configure the Lookaside cache, see lookaside.Config.
+	Coalesce    bool
+	FillTimeout time.Duration
+
+	// QueueSize, FlushInterval, and BatchSize configure the WriteBehind cache, see writebehind.Config.
+	QueueSize     int
+	FlushInterval time.Duration
+	BatchSize     int
+
+	// TTL and RefreshFactor configure the RefreshAhead cache, see refreshahead.Config.
+	TTL           time.Duration
+	RefreshFactor float64
 }
 
 // NilCache is a nil cache driver that returns dial errors. It fixes the issue when the Dial function returns a nil hord.Database this prevents nil pointer errors.
@@ -123,9 +144,31 @@ func Dial(cfg Config) (hord.Database, error) {
 	switch cfg.Type {
 	case Lookaside:
 		return lookaside.Dial(lookaside.Config{
+			Database:    cfg.Database,
+			Cache:       cfg.Cache,
+			Coalesce:    cfg.Coalesce,
+			FillTimeout: cfg.FillTimeout,
+		})
+	case WriteThrough:
+		return writethrough.Dial(writethrough.Config{
 			Database: cfg.Database,
 			Cache:    cfg.Cache,
 		})
+	case WriteBehind:
+		return writebehind.Dial(writebehind.Config{
+			Database:      cfg.Database,
+			Cache:         cfg.Cache,
+			QueueSize:     cfg.QueueSize,
+			FlushInterval: cfg.FlushInterval,
+			BatchSize:     cfg.BatchSize,
+		})
+	case RefreshAhead:
+		return refreshahead.Dial(refreshahead.Config{
+			Database:      cfg.Database,
+			Cache:         cfg.Cache,
+			TTL:           cfg.TTL,
+			RefreshFactor: cfg.RefreshFactor,
+		})
 	case None:
 		return cfg.Database, nil
 	default:
@@ -157,6 +200,34 @@ func (nc *NilCache) Keys() ([]string, error) {
 	return nil, hord.ErrNoDial
 }
 
+func (nc *NilCache) SetupContext(_ context.Context) error {
+	return hord.ErrNoDial
+}
+
+func (nc *NilCache) HealthCheckContext(_ context.Context) error {
+	return hord.ErrNoDial
+}
+
+func (nc *NilCache) GetContext(_ context.Context, _ string) ([]byte, error) {
+	return nil, hord.ErrNoDial
+}
+
+func (nc *NilCache) SetContext(_ context.Context, _ string, _ []byte) error {
+	return hord.ErrNoDial
+}
+
+func (nc *NilCache) DeleteContext(_ context.Context, _ string) error {
+	return hord.ErrNoDial
+}
+
+func (nc *NilCache) KeysContext(_ context.Context) ([]string, error) {
+	return nil, hord.ErrNoDial
+}
+
+func (nc *NilCache) Capabilities() hord.Capabilities {
+	return hord.Capabilities{Context: true}
+}
+
 func (nc *NilCache) Close() {
 
 }
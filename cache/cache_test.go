@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/madflojo/hord"
+	"github.com/madflojo/hord/cache/refreshahead"
 	"github.com/madflojo/hord/drivers/mock"
 )
 
@@ -45,6 +46,39 @@ func TestDial(t *testing.T) {
 			},
 			expectedError: nil,
 		},
+		"Type: WriteThrough": {
+			config: Config{
+				Type:     WriteThrough,
+				Database: &mock.Database{},
+				Cache:    &mock.Database{},
+			},
+			expectedError: nil,
+		},
+		"Type: WriteBehind": {
+			config: Config{
+				Type:     WriteBehind,
+				Database: &mock.Database{},
+				Cache:    &mock.Database{},
+			},
+			expectedError: nil,
+		},
+		"Type: RefreshAhead": {
+			config: Config{
+				Type:     RefreshAhead,
+				Database: &mock.Database{},
+				Cache:    &mock.Database{},
+			},
+			expectedError: nil,
+		},
+		"Type: RefreshAhead, Invalid RefreshFactor": {
+			config: Config{
+				Type:          RefreshAhead,
+				Database:      &mock.Database{},
+				Cache:         &mock.Database{},
+				RefreshFactor: 1,
+			},
+			expectedError: refreshahead.ErrInvalidRefreshFactor,
+		},
 		"Type: None": {
 			config: Config{
 				Type:     None,
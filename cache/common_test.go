@@ -48,6 +48,11 @@ func TestInterfaceHappyPath(t *testing.T) {
 			dbType:      "hashmap",
 			cacheMethod: Lookaside,
 		},
+		"Redis + Cassandra (Write Through)": {
+			cacheType:   "redis",
+			dbType:      "cassandra",
+			cacheMethod: WriteThrough,
+		},
 	}
 
 	// Loop through valid Configs and validate the driver adheres to the Hord interface
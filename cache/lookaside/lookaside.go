@@ -79,12 +79,39 @@ Hord provides a simple abstraction for working with the cache, with easy-to-use
 	if err != nil {
 	    // Handle error
 	}
+
+# Request Coalescing
+
+Set Coalesce to share a single database fetch and cache update between concurrent Get calls that miss the cache for
+the same key. The shared fetch is not canceled by any caller's context, each caller receives its result, including any
+error, or stops waiting once its own context is done. The shared fetch is bounded by FillTimeout, once it elapses the
+waiting callers receive hord.ErrTimeout and later cache misses start a new fetch. A panic during the shared fetch is
+raised again in every waiting caller.
+
+	db, err := lookaside.Dial(lookaside.Config{
+		Database: database,
+		Cache:    cache,
+		Coalesce: true,
+	})
+
+# Circuit Breaking
+
+Wrap the database with the breaker package to keep serving cached reads while the database is unavailable. While the
+database circuit is open, cache hits are returned as normal and cache misses fail fast with hord.ErrUnavailable.
+
+	database, err = breaker.Dial(breaker.Config{Database: database})
+	if err != nil {
+		// Handle connection error
+	}
 */
 package lookaside
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/madflojo/hord"
 )
@@ -93,36 +120,171 @@ import (
 type Config struct {
 	Database hord.Database
 	Cache    hord.Database
+
+	// Coalesce shares a single database fetch and cache update between concurrent cache misses for the same key.
+	Coalesce bool
+
+	// FillTimeout bounds a shared database fetch and cache update when Coalesce is enabled. Defaults to
+	// DefaultFillTimeout.
+	FillTimeout time.Duration
 }
 
+// DefaultFillTimeout is the FillTimeout used when Config.FillTimeout is not set.
+const DefaultFillTimeout = 10 * time.Second
+
 // Lookaside is used to store data in a look-aside caching pattern. It also satisfies the Hord database interface.
 type Lookaside struct {
 	data  hord.Database
 	cache hord.Database
+
+	// fetches holds in-progress cache fills when coalescing is enabled
+	fetches *group
+}
+
+// fetch is an in-progress cache fill shared by concurrent callers.
+type fetch struct {
+	done chan struct{}
+	outcome
+
+	// waiters is the number of callers waiting on the fill
+	waiters int
+}
+
+// outcome is the result of a cache fill.
+type outcome struct {
+	data     []byte
+	err      error
+	panicked interface{}
+}
+
+// group coalesces concurrent cache fills by key.
+type group struct {
+	sync.Mutex
+	fetches map[string]*fetch
+
+	// timeout bounds each cache fill
+	timeout time.Duration
+}
+
+// do runs fn once in the background for concurrent callers with the same key. Each caller waits until fn completes
+// or its own context is done, and receives a copy of the data. If fn panics, the panic is raised in each caller.
+func (g *group) do(ctx context.Context, key string, fn func(context.Context) ([]byte, error)) ([]byte, error) {
+	g.Lock()
+	f, ok := g.fetches[key]
+	if !ok {
+		f = &fetch{done: make(chan struct{})}
+		g.fetches[key] = f
+		go g.run(detached{ctx}, key, f, fn)
+	}
+	f.waiters++
+	g.Unlock()
+
+	select {
+	case <-f.done:
+	case <-ctx.Done():
+		g.Lock()
+		select {
+		case <-f.done:
+			// The fill completed as the caller gave up, deliver its result
+		default:
+			f.waiters--
+			g.Unlock()
+			return nil, ctx.Err()
+		}
+		g.Unlock()
+	}
+
+	if f.panicked != nil {
+		panic(f.panicked)
+	}
+	if f.data == nil {
+		return nil, f.err
+	}
+	return append([]byte{}, f.data...), f.err
 }
 
+// run executes fn, bounded by the group timeout, and releases callers waiting on f.
+func (g *group) run(ctx context.Context, key string, f *fetch, fn func(context.Context) ([]byte, error)) {
+	ctx, cancel := context.WithTimeout(ctx, g.timeout)
+	defer cancel()
+
+	results := make(chan outcome, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				results <- outcome{panicked: r}
+			}
+		}()
+		data, err := fn(ctx)
+		results <- outcome{data: data, err: err}
+	}()
+
+	var result outcome
+	select {
+	case result = <-results:
+	case <-ctx.Done():
+		// Databases that ignore the context may never return, release callers so later misses can fetch again
+		result.err = fmt.Errorf("%w: %w", hord.ErrTimeout, ctx.Err())
+	}
+
+	g.Lock()
+	defer g.Unlock()
+	delete(g.fetches, key)
+	f.outcome = result
+	if result.panicked != nil && f.waiters == 0 {
+		// No caller is left to raise the panic, do not hide it
+		panic(result.panicked)
+	}
+	close(f.done)
+}
+
+// detached is a context that keeps the values of its parent but is never canceled, allowing a shared cache fill to
+// outlive the caller that started it.
+type detached struct {
+	context.Context
+}
+
+func (detached) Deadline() (time.Time, bool) { return time.Time{}, false }
+
+func (detached) Done() <-chan struct{} { return nil }
+
+func (detached) Err() error { return nil }
+
 func Dial(cfg Config) (*Lookaside, error) {
 	if (cfg.Database == nil) || (cfg.Cache == nil) {
 		return nil, hord.ErrInvalidDatabase
 	}
 
-	return &Lookaside{
+	db := &Lookaside{
 		data:  cfg.Database,
 		cache: cfg.Cache,
-	}, nil
+	}
+	if cfg.Coalesce {
+		if cfg.FillTimeout <= 0 {
+			cfg.FillTimeout = DefaultFillTimeout
+		}
+		db.fetches = &group{fetches: make(map[string]*fetch), timeout: cfg.FillTimeout}
+	}
+
+	return db, nil
 }
 
 // Setup will run the Setup function for both the database and the cache.
 func (db *Lookaside) Setup() error {
+	return db.SetupContext(context.Background())
+}
+
+// SetupContext is a context-aware version of Setup.
+func (db *Lookaside) SetupContext(ctx context.Context) error {
 	if db == nil || db.data == nil || db.cache == nil {
 		return hord.ErrNoDial
 	}
 
-	if err := db.data.Setup(); err != nil {
+	if err := hord.WrapContext(db.data).SetupContext(ctx); err != nil {
 		return err
 	}
 
-	if err := db.cache.Setup(); err != nil {
+	if err := hord.WrapContext(db.cache).SetupContext(ctx); err != nil {
 		return err
 	}
 
@@ -131,12 +293,17 @@ func (db *Lookaside) Setup() error {
 
 // HealthCheck will run the HealthCheck function for both the database and the cache.
 func (db *Lookaside) HealthCheck() error {
+	return db.HealthCheckContext(context.Background())
+}
+
+// HealthCheckContext is a context-aware version of HealthCheck.
+func (db *Lookaside) HealthCheckContext(ctx context.Context) error {
 	if db == nil || db.data == nil || db.cache == nil {
 		return hord.ErrNoDial
 	}
 
-	dataErr := db.data.HealthCheck()
-	cacheErr := db.cache.HealthCheck()
+	dataErr := hord.WrapContext(db.data).HealthCheckContext(ctx)
+	cacheErr := hord.WrapContext(db.cache).HealthCheckContext(ctx)
 
 	if dataErr != nil {
 		return dataErr
@@ -149,26 +316,42 @@ func (db *Lookaside) HealthCheck() error {
 
 // Get will get the data from the cache database. If not found, it uses a look-aside pattern to fetch from the data database and store the data in the cache.
 func (db *Lookaside) Get(key string) ([]byte, error) {
+	return db.GetContext(context.Background(), key)
+}
+
+// GetContext is a context-aware version of Get.
+func (db *Lookaside) GetContext(ctx context.Context, key string) ([]byte, error) {
 	if db == nil || db.data == nil || db.cache == nil {
 		return nil, hord.ErrNoDial
 	}
 
 	// Check the cache first
-	data, err := db.cache.Get(key)
+	data, err := hord.WrapContext(db.cache).GetContext(ctx, key)
 	if (err != nil) && !errors.Is(err, hord.ErrNil) {
 		return nil, err
 	} else if !errors.Is(err, hord.ErrNil) {
 		return data, nil
 	}
 
+	if db.fetches != nil {
+		return db.fetches.do(ctx, key, func(ctx context.Context) ([]byte, error) {
+			return db.fill(ctx, key)
+		})
+	}
+
+	return db.fill(ctx, key)
+}
+
+// fill fetches the data from the data database and stores it in the cache.
+func (db *Lookaside) fill(ctx context.Context, key string) ([]byte, error) {
 	// Check the data database
-	data, err = db.data.Get(key)
+	data, err := hord.WrapContext(db.data).GetContext(ctx, key)
 	if err != nil {
 		return nil, err
 	}
 
 	// Update the cache
-	err = db.cache.Set(key, data)
+	err = hord.WrapContext(db.cache).SetContext(ctx, key, data)
 	if err != nil {
 		return data, fmt.Errorf("%w: %w", hord.ErrCacheError, err)
 	}
@@ -178,17 +361,22 @@ func (db *Lookaside) Get(key string) ([]byte, error) {
 
 // Set will set the data in both the data and cache databases.
 func (db *Lookaside) Set(key string, data []byte) error {
+	return db.SetContext(context.Background(), key, data)
+}
+
+// SetContext is a context-aware version of Set.
+func (db *Lookaside) SetContext(ctx context.Context, key string, data []byte) error {
 	if db == nil || db.data == nil || db.cache == nil {
 		return hord.ErrNoDial
 	}
 
-	err := db.data.Set(key, data)
+	err := hord.WrapContext(db.data).SetContext(ctx, key, data)
 	if err != nil {
 		return err
 	}
 
 	// Update cache only if database Set was successful
-	err = db.cache.Set(key, data)
+	err = hord.WrapContext(db.cache).SetContext(ctx, key, data)
 	if err != nil {
 		return err
 	}
@@ -198,12 +386,17 @@ func (db *Lookaside) Set(key string, data []byte) error {
 
 // Delete will delete the data from both the data and cache databases.
 func (db *Lookaside) Delete(key string) error {
+	return db.DeleteContext(context.Background(), key)
+}
+
+// DeleteContext is a context-aware version of Delete.
+func (db *Lookaside) DeleteContext(ctx context.Context, key string) error {
 	if db == nil || db.data == nil || db.cache == nil {
 		return hord.ErrNoDial
 	}
 
-	dataErr := db.data.Delete(key)
-	cacheErr := db.cache.Delete(key)
+	dataErr := hord.WrapContext(db.data).DeleteContext(ctx, key)
+	cacheErr := hord.WrapContext(db.cache).DeleteContext(ctx, key)
 
 	if dataErr != nil {
 		return dataErr
@@ -216,11 +409,16 @@ func (db *Lookaside) Delete(key string) error {
 
 // Keys will return the keys from the data database.
 func (db *Lookaside) Keys() ([]string, error) {
+	return db.KeysContext(context.Background())
+}
+
+// KeysContext is a context-aware version of Keys.
+func (db *Lookaside) KeysContext(ctx context.Context) ([]string, error) {
 	if db == nil || db.data == nil || db.cache == nil {
 		return nil, hord.ErrNoDial
 	}
 
-	return db.data.Keys()
+	return hord.WrapContext(db.data).KeysContext(ctx)
 }
 
 // CacheKeys will return the keys from the cache database.
@@ -242,6 +440,11 @@ func (db *Lookaside) GetDatabase() hord.Database {
 	return db.data
 }
 
+// Capabilities returns the optional features supported by the look-aside cache.
+func (db *Lookaside) Capabilities() hord.Capabilities {
+	return hord.Capabilities{Context: true}
+}
+
 // Close will close the connections to both the database and the cache.
 func (db *Lookaside) Close() {
 	if db != nil && db.data != nil && db.cache != nil {
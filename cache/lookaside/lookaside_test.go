@@ -2,8 +2,12 @@ package lookaside
 
 import (
 	"bytes"
+	"context"
 	"errors"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/madflojo/hord"
 	"github.com/madflojo/hord/drivers/mock"
@@ -532,3 +536,274 @@ func TestClose(t *testing.T) {
 
 	db.Close()
 }
+
+func TestContext(t *testing.T) {
+	databaseConfig := mock.Config{
+		GetFunc: func(_ string) ([]byte, error) {
+			return []byte("database-data"), nil
+		},
+	}
+	cacheConfig := mock.Config{
+		GetFunc: func(_ string) ([]byte, error) {
+			return nil, hord.ErrNil
+		},
+	}
+
+	db, err := setupCache(cacheConfig, databaseConfig)
+	if err != nil {
+		t.Fatalf("Failed to connect to database - %s", err)
+	}
+
+	t.Run("Active Context", func(t *testing.T) {
+		data, err := db.GetContext(context.Background(), "cache-miss")
+		if err != nil {
+			t.Errorf("GetContext() returned error: %s", err)
+		}
+		if string(data) != "database-data" {
+			t.Errorf("GetContext() returned data: %s, expected %s", data, "database-data")
+		}
+	})
+
+	t.Run("Canceled Context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		if err := db.SetupContext(ctx); !errors.Is(err, context.Canceled) {
+			t.Errorf("SetupContext() returned error: %s, expected %s", err, context.Canceled)
+		}
+		if err := db.HealthCheckContext(ctx); !errors.Is(err, context.Canceled) {
+			t.Errorf("HealthCheckContext() returned error: %s, expected %s", err, context.Canceled)
+		}
+		if _, err := db.GetContext(ctx, "cache-miss"); !errors.Is(err, context.Canceled) {
+			t.Errorf("GetContext() returned error: %s, expected %s", err, context.Canceled)
+		}
+		if err := db.SetContext(ctx, "key", []byte("data")); !errors.Is(err, context.Canceled) {
+			t.Errorf("SetContext() returned error: %s, expected %s", err, context.Canceled)
+		}
+		if err := db.DeleteContext(ctx, "key"); !errors.Is(err, context.Canceled) {
+			t.Errorf("DeleteContext() returned error: %s, expected %s", err, context.Canceled)
+		}
+		if _, err := db.KeysContext(ctx); !errors.Is(err, context.Canceled) {
+			t.Errorf("KeysContext() returned error: %s, expected %s", err, context.Canceled)
+		}
+	})
+}
+
+func TestCoalesce(t *testing.T) {
+	var dataGets, cacheSets int32
+	release := make(chan struct{})
+
+	database, err := mock.Dial(mock.Config{
+		GetFunc: func(_ string) ([]byte, error) {
+			atomic.AddInt32(&dataGets, 1)
+			<-release
+			return []byte("database-data"), nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to connect to database - %s", err)
+	}
+	cache, err := mock.Dial(mock.Config{
+		GetFunc: func(_ string) ([]byte, error) {
+			return nil, hord.ErrNil
+		},
+		SetFunc: func(_ string, _ []byte) error {
+			atomic.AddInt32(&cacheSets, 1)
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to connect to cache - %s", err)
+	}
+
+	db, err := Dial(Config{Database: database, Cache: cache, Coalesce: true})
+	if err != nil {
+		t.Fatalf("Failed to connect to database - %s", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			data, err := db.Get("cache-miss")
+			if err != nil || string(data) != "database-data" {
+				t.Errorf("Get() returned %q - %v", data, err)
+			}
+		}()
+	}
+
+	// Wait for the shared fetch to start, then give the other callers time to join it
+	for atomic.LoadInt32(&dataGets) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(50 * time.Millisecond)
+
+	t.Run("Canceled Waiter", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if _, err := db.GetContext(ctx, "cache-miss"); !errors.Is(err, context.Canceled) {
+			t.Errorf("GetContext() returned error: %s, expected %s", err, context.Canceled)
+		}
+	})
+
+	close(release)
+	wg.Wait()
+
+	if n := atomic.LoadInt32(&dataGets); n != 1 {
+		t.Errorf("Expected a single database Get for concurrent misses, got %d", n)
+	}
+	if n := atomic.LoadInt32(&cacheSets); n != 1 {
+		t.Errorf("Expected a single cache Set for concurrent misses, got %d", n)
+	}
+
+	t.Run("Later Misses Fetch Again", func(t *testing.T) {
+		if _, err := db.Get("cache-miss"); err != nil {
+			t.Errorf("Get() returned error: %s", err)
+		}
+		if n := atomic.LoadInt32(&dataGets); n != 2 {
+			t.Errorf("Expected a new database Get once the shared fetch completed, got %d", n)
+		}
+	})
+}
+
+func TestCoalesceCanceledCaller(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{}, 1)
+
+	database, err := mock.Dial(mock.Config{
+		GetFunc: func(_ string) ([]byte, error) {
+			started <- struct{}{}
+			<-release
+			return []byte("database-data"), nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to connect to database - %s", err)
+	}
+	cache, err := mock.Dial(mock.Config{
+		GetFunc: func(_ string) ([]byte, error) {
+			return nil, hord.ErrNil
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to connect to cache - %s", err)
+	}
+
+	db, err := Dial(Config{Database: database, Cache: cache, Coalesce: true})
+	if err != nil {
+		t.Fatalf("Failed to connect to database - %s", err)
+	}
+
+	// The first caller starts the shared fetch, then gives up
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error, 1)
+	go func() {
+		_, err := db.GetContext(ctx, "cache-miss")
+		first <- err
+	}()
+	<-started
+
+	second := make(chan error, 1)
+	go func() {
+		data, err := db.Get("cache-miss")
+		if err == nil && string(data) != "database-data" {
+			err = errors.New("unexpected data " + string(data))
+		}
+		second <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	cancel()
+	if err := <-first; !errors.Is(err, context.Canceled) {
+		t.Errorf("GetContext() returned error: %v, expected %s", err, context.Canceled)
+	}
+
+	close(release)
+	if err := <-second; err != nil {
+		t.Errorf("Expected waiting caller to receive the shared result, got %s", err)
+	}
+}
+
+func TestCoalescePanic(t *testing.T) {
+	database, err := mock.Dial(mock.Config{
+		GetFunc: func(_ string) ([]byte, error) {
+			panic("database panic")
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to connect to database - %s", err)
+	}
+	cache, err := mock.Dial(mock.Config{
+		GetFunc: func(_ string) ([]byte, error) {
+			return nil, hord.ErrNil
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to connect to cache - %s", err)
+	}
+
+	db, err := Dial(Config{Database: database, Cache: cache, Coalesce: true})
+	if err != nil {
+		t.Fatalf("Failed to connect to database - %s", err)
+	}
+
+	func() {
+		defer func() {
+			if r := recover(); r != "database panic" {
+				t.Errorf("Expected the panic to be raised in the caller, got %v", r)
+			}
+		}()
+		data, err := db.Get("cache-miss")
+		t.Errorf("Expected a panic from a panicking fill, got %q - %v", data, err)
+	}()
+
+	t.Run("Fill Removed", func(t *testing.T) {
+		db.fetches.Lock()
+		defer db.fetches.Unlock()
+		if len(db.fetches.fetches) != 0 {
+			t.Errorf("Expected the panicked fill to be removed")
+		}
+	})
+}
+
+func TestCoalesceTimeout(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	var dataGets int32
+	database, err := mock.Dial(mock.Config{
+		GetFunc: func(_ string) ([]byte, error) {
+			// The first fetch hangs, ignoring the context
+			if atomic.AddInt32(&dataGets, 1) == 1 {
+				<-release
+			}
+			return []byte("database-data"), nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to connect to database - %s", err)
+	}
+	cache, err := mock.Dial(mock.Config{
+		GetFunc: func(_ string) ([]byte, error) {
+			return nil, hord.ErrNil
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to connect to cache - %s", err)
+	}
+
+	db, err := Dial(Config{Database: database, Cache: cache, Coalesce: true, FillTimeout: 20 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("Failed to connect to database - %s", err)
+	}
+
+	if _, err := db.Get("cache-miss"); !errors.Is(err, hord.ErrTimeout) {
+		t.Errorf("Get() returned error: %v, expected %s", err, hord.ErrTimeout)
+	}
+
+	data, err := db.Get("cache-miss")
+	if err != nil || string(data) != "database-data" {
+		t.Errorf("Expected a new fetch once the shared fetch timed out, got %q - %v", data, err)
+	}
+}
@@ -0,0 +1,405 @@
+/*
+Package refreshahead provides a Hord database driver for a refresh-ahead cache. Cached entries that are accessed after
+a configurable fraction of their TTL has elapsed are refreshed from the database in the background, keeping hot keys
+cached without requests falling through to the database when they expire. To use this driver, import it as follows:
+
+	import (
+	    "github.com/madflojo/hord"
+	    "github.com/madflojo/hord/cache/refreshahead"
+	)
+
+# Connecting to the Database
+
+Use the Dial() function to create a new client for interacting with the cache.
+
+	// Handle database connection
+	var database hord.Database
+	...
+
+	// Handle cache connection
+	var cache hord.Database
+	...
+
+	db, err := refreshahead.Dial(refreshahead.Config{
+		Database:      database,
+		Cache:         cache,
+		TTL:           time.Minute,
+		RefreshFactor: 0.8,
+	})
+	if err != nil {
+	    // Handle connection error
+	}
+	defer db.Close()
+
+# Refreshing
+
+Each cached entry records when it was loaded from the database. A Get for an entry older than RefreshFactor * TTL
+returns the cached value immediately and refreshes the entry from the database in the background. Entries that are
+never accessed past that point simply expire. Only one refresh runs per key at a time, and failed refreshes are passed
+to OnError.
+
+Entries are cached using SetWithTTL when the cache supports TTLs. Entries older than TTL are never returned, even if
+the cache does not support TTLs, and are reloaded from the database as a cache miss.
+
+Entry ages are stored with the cached value, values within the cache should only be read through this driver.
+*/
+package refreshahead
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"github.com/madflojo/hord"
+)
+
+const (
+	// DefaultTTL is the TTL used when Config.TTL is not set.
+	DefaultTTL = 5 * time.Minute
+
+	// DefaultRefreshFactor is the RefreshFactor used when Config.RefreshFactor is not set.
+	DefaultRefreshFactor = 0.75
+)
+
+// stripes is the number of locks used to serialize operations by key.
+const stripes = 64
+
+// headerSize is the size of the load time stored before each cached value.
+const headerSize = 8
+
+var (
+	// ErrInvalidRefreshFactor is returned when the RefreshFactor is not greater than 0 and less than 1.
+	ErrInvalidRefreshFactor = errors.New("RefreshFactor must be greater than 0 and less than 1")
+)
+
+// Config provides the configuration options for the RefreshAhead driver.
+type Config struct {
+	Database hord.Database
+	Cache    hord.Database
+
+	// TTL is how long entries remain cached. Defaults to DefaultTTL.
+	TTL time.Duration
+
+	// RefreshFactor is the fraction of TTL after which an accessed entry is refreshed in the background. It must be
+	// less than 1, as entries are not returned once their TTL has elapsed. Defaults to DefaultRefreshFactor.
+	RefreshFactor float64
+
+	// OnError is called when a background refresh fails.
+	OnError func(key string, err error)
+}
+
+// RefreshAhead is used to store data in a refresh-ahead caching pattern. It also satisfies the Hord database
+// interface.
+type RefreshAhead struct {
+	sync.Mutex
+
+	cfg   Config
+	data  hord.Database
+	cache hord.Database
+
+	// refreshAfter is the age after which entries are refreshed
+	refreshAfter time.Duration
+
+	// now returns the current time
+	now func() time.Time
+
+	// refreshing holds keys with a refresh in progress
+	refreshing map[string]struct{}
+
+	// closed is set once Close is called
+	closed bool
+
+	// wg tracks background refreshes
+	wg sync.WaitGroup
+
+	// locks serialize writes and cache fills for keys sharing a stripe
+	locks [stripes]sync.Mutex
+}
+
+// Dial will create a new RefreshAhead driver. Close must be called to wait for background refreshes.
+func Dial(cfg Config) (*RefreshAhead, error) {
+	if (cfg.Database == nil) || (cfg.Cache == nil) {
+		return nil, hord.ErrInvalidDatabase
+	}
+
+	if cfg.TTL <= 0 {
+		cfg.TTL = DefaultTTL
+	}
+	if cfg.RefreshFactor == 0 {
+		cfg.RefreshFactor = DefaultRefreshFactor
+	}
+	if cfg.RefreshFactor < 0 || cfg.RefreshFactor >= 1 {
+		return nil, ErrInvalidRefreshFactor
+	}
+
+	return &RefreshAhead{
+		cfg:          cfg,
+		data:         cfg.Database,
+		cache:        cfg.Cache,
+		refreshAfter: time.Duration(float64(cfg.TTL) * cfg.RefreshFactor),
+		now:          time.Now,
+		refreshing:   make(map[string]struct{}),
+	}, nil
+}
+
+// lock locks the stripe for key and returns the function to unlock it.
+func (db *RefreshAhead) lock(key string) func() {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	m := &db.locks[h.Sum32()%stripes]
+	m.Lock()
+	return m.Unlock
+}
+
+// store writes data to the cache along with the time it was loaded.
+func (db *RefreshAhead) store(ctx context.Context, key string, data []byte) error {
+	value := make([]byte, headerSize+len(data))
+	binary.BigEndian.PutUint64(value, uint64(db.now().UnixNano()))
+	copy(value[headerSize:], data)
+
+	if tdb, ok := db.cache.(hord.TTLDatabase); ok {
+		err := tdb.SetWithTTL(key, value, db.cfg.TTL)
+		if !errors.Is(err, hord.ErrNotSupported) {
+			return err
+		}
+	}
+	return hord.WrapContext(db.cache).SetContext(ctx, key, value)
+}
+
+// load fetches key from the data database and stores it in the cache.
+func (db *RefreshAhead) load(ctx context.Context, key string) ([]byte, error) {
+	unlock := db.lock(key)
+	defer unlock()
+
+	data, err := hord.WrapContext(db.data).GetContext(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.store(ctx, key, data)
+	if err != nil {
+		return data, fmt.Errorf("%w: %w", hord.ErrCacheError, err)
+	}
+	return data, nil
+}
+
+// refresh reloads key from the data database in the background, unless a refresh is already running.
+func (db *RefreshAhead) refresh(key string) {
+	db.Lock()
+	defer db.Unlock()
+	if _, ok := db.refreshing[key]; ok || db.closed {
+		return
+	}
+	db.refreshing[key] = struct{}{}
+	db.wg.Add(1)
+
+	go func() {
+		defer db.wg.Done()
+		defer func() {
+			db.Lock()
+			delete(db.refreshing, key)
+			db.Unlock()
+		}()
+
+		_, err := db.load(context.Background(), key)
+		if errors.Is(err, hord.ErrNil) {
+			// Removed from the database, drop the cached entry
+			err = hord.WrapContext(db.cache).DeleteContext(context.Background(), key)
+		}
+		if err != nil && db.cfg.OnError != nil {
+			db.cfg.OnError(key, err)
+		}
+	}()
+}
+
+// Setup will run the Setup function for both the database and the cache.
+func (db *RefreshAhead) Setup() error {
+	return db.SetupContext(context.Background())
+}
+
+// SetupContext is a context-aware version of Setup.
+func (db *RefreshAhead) SetupContext(ctx context.Context) error {
+	if db == nil || db.data == nil || db.cache == nil {
+		return hord.ErrNoDial
+	}
+
+	if err := hord.WrapContext(db.data).SetupContext(ctx); err != nil {
+		return err
+	}
+
+	if err := hord.WrapContext(db.cache).SetupContext(ctx); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// HealthCheck will run the HealthCheck function for both the database and the cache.
+func (db *RefreshAhead) HealthCheck() error {
+	return db.HealthCheckContext(context.Background())
+}
+
+// HealthCheckContext is a context-aware version of HealthCheck.
+func (db *RefreshAhead) HealthCheckContext(ctx context.Context) error {
+	if db == nil || db.data == nil || db.cache == nil {
+		return hord.ErrNoDial
+	}
+
+	dataErr := hord.WrapContext(db.data).HealthCheckContext(ctx)
+	cacheErr := hord.WrapContext(db.cache).HealthCheckContext(ctx)
+
+	if dataErr != nil {
+		return dataErr
+	} else if cacheErr != nil {
+		return cacheErr
+	}
+
+	return nil
+}
+
+// Get will get the data from the cache database, refreshing it in the background once it is past RefreshFactor of its
+// TTL. If not found, the data is fetched from the data database and stored in the cache.
+func (db *RefreshAhead) Get(key string) ([]byte, error) {
+	return db.GetContext(context.Background(), key)
+}
+
+// GetContext is a context-aware version of Get.
+func (db *RefreshAhead) GetContext(ctx context.Context, key string) ([]byte, error) {
+	if db == nil || db.data == nil || db.cache == nil {
+		return nil, hord.ErrNoDial
+	}
+
+	// Check the cache first
+	value, err := hord.WrapContext(db.cache).GetContext(ctx, key)
+	if (err != nil) && !errors.Is(err, hord.ErrNil) {
+		return nil, err
+	}
+
+	if err == nil && len(value) >= headerSize {
+		age := db.now().Sub(time.Unix(0, int64(binary.BigEndian.Uint64(value))))
+		if age < db.cfg.TTL {
+			if age >= db.refreshAfter {
+				db.refresh(key)
+			}
+			return value[headerSize:], nil
+		}
+	}
+
+	// Expired, missing, or unreadable entries are loaded from the data database
+	return db.load(ctx, key)
+}
+
+// Set will set the data in both the data and cache databases.
+func (db *RefreshAhead) Set(key string, data []byte) error {
+	return db.SetContext(context.Background(), key, data)
+}
+
+// SetContext is a context-aware version of Set.
+func (db *RefreshAhead) SetContext(ctx context.Context, key string, data []byte) error {
+	if db == nil || db.data == nil || db.cache == nil {
+		return hord.ErrNoDial
+	}
+
+	unlock := db.lock(key)
+	defer unlock()
+
+	err := hord.WrapContext(db.data).SetContext(ctx, key, data)
+	if err != nil {
+		return err
+	}
+
+	// Update cache only if database Set was successful
+	err = db.store(ctx, key, data)
+	if err != nil {
+		return fmt.Errorf("%w: %w", hord.ErrCacheError, err)
+	}
+
+	return nil
+}
+
+// Delete will delete the data from both the data and cache databases.
+func (db *RefreshAhead) Delete(key string) error {
+	return db.DeleteContext(context.Background(), key)
+}
+
+// DeleteContext is a context-aware version of Delete.
+func (db *RefreshAhead) DeleteContext(ctx context.Context, key string) error {
+	if db == nil || db.data == nil || db.cache == nil {
+		return hord.ErrNoDial
+	}
+
+	unlock := db.lock(key)
+	defer unlock()
+
+	dataErr := hord.WrapContext(db.data).DeleteContext(ctx, key)
+	cacheErr := hord.WrapContext(db.cache).DeleteContext(ctx, key)
+
+	if dataErr != nil {
+		return dataErr
+	} else if cacheErr != nil {
+		return cacheErr
+	}
+
+	return nil
+}
+
+// Keys will return the keys from the data database.
+func (db *RefreshAhead) Keys() ([]string, error) {
+	return db.KeysContext(context.Background())
+}
+
+// KeysContext is a context-aware version of Keys.
+func (db *RefreshAhead) KeysContext(ctx context.Context) ([]string, error) {
+	if db == nil || db.data == nil || db.cache == nil {
+		return nil, hord.ErrNoDial
+	}
+
+	return hord.WrapContext(db.data).KeysContext(ctx)
+}
+
+// CacheKeys will return the keys from the cache database.
+func (db *RefreshAhead) CacheKeys() ([]string, error) {
+	if db == nil || db.data == nil || db.cache == nil {
+		return nil, hord.ErrNoDial
+	}
+
+	return db.cache.Keys()
+}
+
+// GetCache will return the cache database.
+func (db *RefreshAhead) GetCache() hord.Database {
+	return db.cache
+}
+
+// GetDatabase will return the data database.
+func (db *RefreshAhead) GetDatabase() hord.Database {
+	return db.data
+}
+
+// Capabilities returns the optional features supported by the refresh-ahead cache.
+func (db *RefreshAhead) Capabilities() hord.Capabilities {
+	return hord.Capabilities{Context: true}
+}
+
+// Close will wait for background refreshes to finish and close the connections to both the database and the cache.
+func (db *RefreshAhead) Close() {
+	if db == nil || db.data == nil || db.cache == nil {
+		return
+	}
+
+	db.Lock()
+	if db.closed {
+		db.Unlock()
+		return
+	}
+	db.closed = true
+	db.Unlock()
+
+	db.wg.Wait()
+	db.data.Close()
+	db.cache.Close()
+}
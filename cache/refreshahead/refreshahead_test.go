@@ -0,0 +1,342 @@
+package refreshahead
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/madflojo/hord"
+	"github.com/madflojo/hord/drivers/hashmap"
+	"github.com/madflojo/hord/drivers/mock"
+)
+
+// Test Errors used for testing purposes
+var (
+	ErrDatabaseTest = errors.New("database error")
+	ErrCacheTest    = errors.New("cache error")
+)
+
+// clock is a manually advanced clock.
+type clock struct {
+	sync.Mutex
+	t time.Time
+}
+
+func (c *clock) Now() time.Time {
+	c.Lock()
+	defer c.Unlock()
+	return c.t
+}
+
+func (c *clock) Advance(d time.Duration) {
+	c.Lock()
+	defer c.Unlock()
+	c.t = c.t.Add(d)
+}
+
+// setupCache is a helper function to create a new RefreshAhead driver with a counting database and a manual clock.
+func setupCache(t *testing.T, cfg Config, dataErr error) (*RefreshAhead, *clock, *int32) {
+	t.Helper()
+
+	var loads int32
+	store, err := hashmap.Dial(hashmap.Config{})
+	if err != nil {
+		t.Fatalf("Failed to create database - %s", err)
+	}
+	data, err := mock.Dial(mock.Config{
+		GetFunc: func(key string) ([]byte, error) {
+			atomic.AddInt32(&loads, 1)
+			if dataErr != nil {
+				return nil, dataErr
+			}
+			return store.Get(key)
+		},
+		SetFunc:    store.Set,
+		DeleteFunc: store.Delete,
+		KeysFunc:   store.Keys,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create database - %s", err)
+	}
+
+	cache, err := hashmap.Dial(hashmap.Config{})
+	if err != nil {
+		t.Fatalf("Failed to create cache - %s", err)
+	}
+
+	cfg.Database = data
+	cfg.Cache = cache
+	db, err := Dial(cfg)
+	if err != nil {
+		t.Fatalf("Failed to connect to database - %s", err)
+	}
+
+	clk := &clock{t: time.Now()}
+	db.now = clk.Now
+	return db, clk, &loads
+}
+
+func TestDial(t *testing.T) {
+	unitTests := map[string]struct {
+		config        Config
+		expectedError error
+	}{
+		"No Config":   {config: Config{}, expectedError: hord.ErrInvalidDatabase},
+		"No Database": {config: Config{Cache: &mock.Database{}}, expectedError: hord.ErrInvalidDatabase},
+		"No Cache":    {config: Config{Database: &mock.Database{}}, expectedError: hord.ErrInvalidDatabase},
+		"Invalid Refresh Factor": {
+			config:        Config{Database: &mock.Database{}, Cache: &mock.Database{}, RefreshFactor: 1.5},
+			expectedError: ErrInvalidRefreshFactor,
+		},
+		"Refresh Factor Of One": {
+			config:        Config{Database: &mock.Database{}, Cache: &mock.Database{}, RefreshFactor: 1},
+			expectedError: ErrInvalidRefreshFactor,
+		},
+		"Negative Refresh Factor": {
+			config:        Config{Database: &mock.Database{}, Cache: &mock.Database{}, RefreshFactor: -0.5},
+			expectedError: ErrInvalidRefreshFactor,
+		},
+		"Happy Path": {config: Config{Database: &mock.Database{}, Cache: &mock.Database{}}, expectedError: nil},
+	}
+
+	for name, test := range unitTests {
+		t.Run(name, func(t *testing.T) {
+			_, err := Dial(test.config)
+			if !errors.Is(err, test.expectedError) {
+				t.Errorf("Dial(%v) returned error: %s, expected %s", test.config, err, test.expectedError)
+			}
+		})
+	}
+}
+
+func TestRefreshAhead(t *testing.T) {
+	db, clk, loads := setupCache(t, Config{TTL: time.Minute, RefreshFactor: 0.5}, nil)
+	defer db.Close()
+
+	if err := db.Setup(); err != nil {
+		t.Fatalf("Setup() returned error: %s", err)
+	}
+	if err := db.HealthCheck(); err != nil {
+		t.Fatalf("HealthCheck() returned error: %s", err)
+	}
+
+	if err := db.Set("key", []byte("v1")); err != nil {
+		t.Fatalf("Set() returned error: %s", err)
+	}
+
+	t.Run("Fresh Entry", func(t *testing.T) {
+		clk.Advance(20 * time.Second)
+		data, err := db.Get("key")
+		if err != nil || string(data) != "v1" {
+			t.Errorf("Get() returned %q - %v", data, err)
+		}
+		db.wg.Wait()
+		if atomic.LoadInt32(loads) != 0 {
+			t.Errorf("Expected fresh entry to be served from cache, %d database loads", atomic.LoadInt32(loads))
+		}
+	})
+
+	t.Run("Refresh", func(t *testing.T) {
+		// Update the database directly, the cache still holds the old value
+		if err := db.data.Set("key", []byte("v2")); err != nil {
+			t.Fatalf("Unexpected error - %s", err)
+		}
+
+		clk.Advance(15 * time.Second)
+		data, err := db.Get("key")
+		if err != nil || string(data) != "v1" {
+			t.Errorf("Expected cached value while refreshing, got %q - %v", data, err)
+		}
+		db.wg.Wait()
+		if atomic.LoadInt32(loads) != 1 {
+			t.Errorf("Expected a single background refresh, %d database loads", atomic.LoadInt32(loads))
+		}
+
+		// Refreshed entries are fresh again
+		clk.Advance(20 * time.Second)
+		data, err = db.Get("key")
+		if err != nil || string(data) != "v2" {
+			t.Errorf("Expected refreshed value, got %q - %v", data, err)
+		}
+		db.wg.Wait()
+		if atomic.LoadInt32(loads) != 1 {
+			t.Errorf("Expected refreshed entry to be served from cache, %d database loads", atomic.LoadInt32(loads))
+		}
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		clk.Advance(2 * time.Minute)
+		data, err := db.Get("key")
+		if err != nil || string(data) != "v2" {
+			t.Errorf("Get() returned %q - %v", data, err)
+		}
+		if atomic.LoadInt32(loads) != 2 {
+			t.Errorf("Expected expired entry to be loaded synchronously, %d database loads", atomic.LoadInt32(loads))
+		}
+	})
+
+	t.Run("Removed From Database", func(t *testing.T) {
+		if err := db.data.Delete("key"); err != nil {
+			t.Fatalf("Unexpected error - %s", err)
+		}
+		clk.Advance(45 * time.Second)
+		if _, err := db.Get("key"); err != nil {
+			t.Errorf("Expected cached value while refreshing, got %v", err)
+		}
+		db.wg.Wait()
+		if _, err := db.cache.Get("key"); !errors.Is(err, hord.ErrNil) {
+			t.Errorf("Expected cache entry to be removed, got %v", err)
+		}
+		if _, err := db.Get("key"); !errors.Is(err, hord.ErrNil) {
+			t.Errorf("Expected ErrNil, got %v", err)
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		if err := db.Set("other", []byte("value")); err != nil {
+			t.Fatalf("Set() returned error: %s", err)
+		}
+		if err := db.Delete("other"); err != nil {
+			t.Fatalf("Delete() returned error: %s", err)
+		}
+		keys, err := db.Keys()
+		if err != nil || len(keys) != 0 {
+			t.Errorf("Keys() returned %v - %v, expected no keys", keys, err)
+		}
+	})
+}
+
+func TestSingleRefresh(t *testing.T) {
+	db, clk, loads := setupCache(t, Config{TTL: time.Minute}, nil)
+	defer db.Close()
+
+	if err := db.Set("key", []byte("value")); err != nil {
+		t.Fatalf("Set() returned error: %s", err)
+	}
+
+	// Hold the key lock so refreshes stay in progress while concurrent requests arrive
+	unlock := db.lock("key")
+	clk.Advance(50 * time.Second)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if data, err := db.Get("key"); err != nil || string(data) != "value" {
+				t.Errorf("Get() returned %q - %v", data, err)
+			}
+		}()
+	}
+	wg.Wait()
+	unlock()
+	db.wg.Wait()
+
+	if atomic.LoadInt32(loads) != 1 {
+		t.Errorf("Expected a single refresh for concurrent requests, %d database loads", atomic.LoadInt32(loads))
+	}
+}
+
+func TestRefreshError(t *testing.T) {
+	var errs int32
+	db, clk, _ := setupCache(t, Config{
+		TTL: time.Minute,
+		OnError: func(key string, err error) {
+			atomic.AddInt32(&errs, 1)
+			if key != "key" || !errors.Is(err, ErrDatabaseTest) {
+				t.Errorf("OnError(%s, %v) called, expected %s", key, err, ErrDatabaseTest)
+			}
+		},
+	}, ErrDatabaseTest)
+	defer db.Close()
+
+	if err := db.Set("key", []byte("value")); err != nil {
+		t.Fatalf("Set() returned error: %s", err)
+	}
+
+	clk.Advance(50 * time.Second)
+	if data, err := db.Get("key"); err != nil || string(data) != "value" {
+		t.Errorf("Expected cached value, got %q - %v", data, err)
+	}
+	db.wg.Wait()
+
+	if atomic.LoadInt32(&errs) != 1 {
+		t.Errorf("Expected OnError to be called once, called %d times", atomic.LoadInt32(&errs))
+	}
+	if data, err := db.Get("key"); err != nil || string(data) != "value" {
+		t.Errorf("Expected cached value to be kept after a failed refresh, got %q - %v", data, err)
+	}
+}
+
+func TestSetCacheError(t *testing.T) {
+	data, err := hashmap.Dial(hashmap.Config{})
+	if err != nil {
+		t.Fatalf("Failed to create database - %s", err)
+	}
+	cache, err := mock.Dial(mock.Config{
+		SetFunc:        func(_ string, _ []byte) error { return ErrCacheTest },
+		SetWithTTLFunc: func(_ string, _ []byte, _ time.Duration) error { return ErrCacheTest },
+	})
+	if err != nil {
+		t.Fatalf("Failed to create cache - %s", err)
+	}
+
+	db, err := Dial(Config{Database: data, Cache: cache})
+	if err != nil {
+		t.Fatalf("Failed to connect to database - %s", err)
+	}
+
+	err = db.Set("key", []byte("value"))
+	if !errors.Is(err, hord.ErrCacheError) || !errors.Is(err, ErrCacheTest) {
+		t.Errorf("Set() returned error: %v, expected %s wrapping %s", err, hord.ErrCacheError, ErrCacheTest)
+	}
+}
+
+func TestClose(t *testing.T) {
+	db, clk, loads := setupCache(t, Config{TTL: time.Minute}, nil)
+
+	if err := db.Set("key", []byte("value")); err != nil {
+		t.Fatalf("Set() returned error: %s", err)
+	}
+
+	clk.Advance(50 * time.Second)
+	if _, err := db.Get("key"); err != nil {
+		t.Fatalf("Get() returned error: %s", err)
+	}
+	db.Close()
+	db.Close()
+
+	if atomic.LoadInt32(loads) != 1 {
+		t.Errorf("Expected Close to wait for refresh, %d database loads", atomic.LoadInt32(loads))
+	}
+
+	db.refresh("key")
+	if len(db.refreshing) != 0 {
+		t.Errorf("Expected no refreshes after Close")
+	}
+}
+
+func TestNilDatabase(t *testing.T) {
+	var db *RefreshAhead
+	if err := db.Setup(); !errors.Is(err, hord.ErrNoDial) {
+		t.Errorf("Setup() returned error: %s, expected %s", err, hord.ErrNoDial)
+	}
+	if err := db.HealthCheck(); !errors.Is(err, hord.ErrNoDial) {
+		t.Errorf("HealthCheck() returned error: %s, expected %s", err, hord.ErrNoDial)
+	}
+	if _, err := db.Get("key"); !errors.Is(err, hord.ErrNoDial) {
+		t.Errorf("Get() returned error: %s, expected %s", err, hord.ErrNoDial)
+	}
+	if err := db.Set("key", []byte("data")); !errors.Is(err, hord.ErrNoDial) {
+		t.Errorf("Set() returned error: %s, expected %s", err, hord.ErrNoDial)
+	}
+	if err := db.Delete("key"); !errors.Is(err, hord.ErrNoDial) {
+		t.Errorf("Delete() returned error: %s, expected %s", err, hord.ErrNoDial)
+	}
+	if _, err := db.Keys(); !errors.Is(err, hord.ErrNoDial) {
+		t.Errorf("Keys() returned error: %s, expected %s", err, hord.ErrNoDial)
+	}
+	db.Close()
+}
@@ -0,0 +1,677 @@
+/*
+Package writebehind provides a Hord database driver for a write-behind (write-back) cache. Writes are acknowledged once
+they are stored in the cache and are written to the database asynchronously in batches. To use this driver, import it
+as follows:
+
+	import (
+	    "github.com/madflojo/hord"
+	    "github.com/madflojo/hord/cache/writebehind"
+	)
+
+# Connecting to the Database
+
+Use the Dial() function to create a new client for interacting with the cache.
+
+	// Handle database connection
+	var database hord.Database
+	...
+
+	// Handle cache connection
+	var cache hord.Database
+	...
+
+	db, err := writebehind.Dial(writebehind.Config{
+		Database:      database,
+		Cache:         cache,
+		FlushInterval: 500 * time.Millisecond,
+		BatchSize:     200,
+		OnError: func(key string, err error) {
+			// Handle failed database write
+		},
+	})
+	if err != nil {
+	    // Handle connection error
+	}
+	defer db.Close()
+
+# Flushing
+
+Pending writes are queued and flushed to the database every FlushInterval, or sooner once BatchSize keys are pending.
+Repeated writes to a key that has not yet been flushed are coalesced, only the latest value is written to the
+database. The queue holds at most QueueSize keys, once full, writes block until space is available.
+
+Use the Flush() function to write the changes pending when it is called to the database immediately. Close() stops
+the background flusher and drains any pending writes before closing the database and cache.
+
+	err := db.Flush()
+	if err != nil {
+	    // Handle failed database writes
+	}
+
+Failed database writes are not retried. Each failure is passed to OnError, the cache still holds the latest value and
+the key may be written again to retry.
+
+# Reads
+
+Get returns pending writes first, followed by the cache, and finally the database. Values read from the database are
+stored in the cache.
+*/
+package writebehind
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"github.com/madflojo/hord"
+)
+
+const (
+	// DefaultQueueSize is the QueueSize used when Config.QueueSize is not set.
+	DefaultQueueSize = 10000
+
+	// DefaultFlushInterval is the FlushInterval used when Config.FlushInterval is not set.
+	DefaultFlushInterval = time.Second
+
+	// DefaultBatchSize is the BatchSize used when Config.BatchSize is not set.
+	DefaultBatchSize = 100
+)
+
+// stripes is the number of locks used to serialize operations by key.
+const stripes = 64
+
+// Config provides the configuration options for the WriteBehind driver.
+type Config struct {
+	Database hord.Database
+	Cache    hord.Database
+
+	// QueueSize is the maximum number of keys waiting to be written to the database. Defaults to DefaultQueueSize.
+	QueueSize int
+
+	// FlushInterval is how often pending writes are flushed to the database. Defaults to DefaultFlushInterval.
+	FlushInterval time.Duration
+
+	// BatchSize is the maximum number of keys written to the database at once. Defaults to DefaultBatchSize.
+	BatchSize int
+
+	// OnError is called for each key that failed to be written to the database.
+	OnError func(key string, err error)
+}
+
+// entry is a pending change to a key.
+type entry struct {
+	data    []byte
+	deleted bool
+}
+
+// WriteBehind is used to store data in a write-behind caching pattern. It also satisfies the Hord database interface.
+type WriteBehind struct {
+	sync.Mutex
+
+	cfg   Config
+	data  hord.Database
+	cache hord.Database
+
+	// pending holds changes not yet flushed, order holds their keys in the order they were first queued
+	pending map[string]*entry
+	order   []string
+
+	// inflight holds changes currently being written to the database
+	inflight map[string]*entry
+
+	// drained is closed and replaced whenever queue space is freed
+	drained chan struct{}
+
+	// closed is set once Close is called
+	closed bool
+
+	// flushing serializes flushes so changes to a key are written in order
+	flushing sync.Mutex
+
+	// locks serialize writes and cache fills for keys sharing a stripe
+	locks [stripes]sync.Mutex
+
+	kick      chan struct{}
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// Dial will create a new WriteBehind driver and start flushing writes in the background. Close must be called to stop
+// the background flusher.
+func Dial(cfg Config) (*WriteBehind, error) {
+	if (cfg.Database == nil) || (cfg.Cache == nil) {
+		return nil, hord.ErrInvalidDatabase
+	}
+
+	if cfg.QueueSize <= 0 {
+		cfg.QueueSize = DefaultQueueSize
+	}
+	if cfg.FlushInterval <= 0 {
+		cfg.FlushInterval = DefaultFlushInterval
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = DefaultBatchSize
+	}
+
+	db := &WriteBehind{
+		cfg:      cfg,
+		data:     cfg.Database,
+		cache:    cfg.Cache,
+		pending:  make(map[string]*entry),
+		inflight: make(map[string]*entry),
+		drained:  make(chan struct{}),
+		kick:     make(chan struct{}, 1),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	go db.run()
+	return db, nil
+}
+
+// run flushes pending writes every FlushInterval or when signaled, until stopped.
+func (db *WriteBehind) run() {
+	defer close(db.done)
+
+	ticker := time.NewTicker(db.cfg.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-db.stop:
+			return
+		case <-ticker.C:
+		case <-db.kick:
+		}
+		_ = db.FlushContext(context.Background())
+	}
+}
+
+// signal asks the background flusher to flush without waiting for the next interval.
+func (db *WriteBehind) signal() {
+	select {
+	case db.kick <- struct{}{}:
+	default:
+	}
+}
+
+// lock locks the stripe for key and returns the function to unlock it.
+func (db *WriteBehind) lock(key string) func() {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	m := &db.locks[h.Sum32()%stripes]
+	m.Lock()
+	return m.Unlock
+}
+
+// lookup returns the latest unflushed change for key, if any.
+func (db *WriteBehind) lookup(key string) (*entry, bool) {
+	db.Lock()
+	defer db.Unlock()
+	if e, ok := db.pending[key]; ok {
+		return e, true
+	}
+	e, ok := db.inflight[key]
+	return e, ok
+}
+
+// isClosed returns true once Close has been called.
+func (db *WriteBehind) isClosed() bool {
+	db.Lock()
+	defer db.Unlock()
+	return db.closed
+}
+
+// enqueue queues a change for key, coalescing with any pending change. It blocks while the queue is full.
+func (db *WriteBehind) enqueue(ctx context.Context, key string, e *entry) error {
+	for {
+		db.Lock()
+		if db.closed {
+			db.Unlock()
+			return hord.ErrClosed
+		}
+
+		_, queued := db.pending[key]
+		if queued || len(db.pending) < db.cfg.QueueSize {
+			if !queued {
+				db.order = append(db.order, key)
+			}
+			db.pending[key] = e
+			full := len(db.pending) >= db.cfg.BatchSize
+			db.Unlock()
+
+			if full {
+				db.signal()
+			}
+			return nil
+		}
+
+		drained := db.drained
+		db.Unlock()
+		db.signal()
+
+		select {
+		case <-drained:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// queued returns the number of keys waiting in the queue.
+func (db *WriteBehind) queued() int {
+	db.Lock()
+	defer db.Unlock()
+	return len(db.order)
+}
+
+// take removes up to limit pending changes, and no more than BatchSize, from the queue, marking them as in-flight.
+func (db *WriteBehind) take(limit int) ([]string, map[string]*entry) {
+	db.Lock()
+	defer db.Unlock()
+
+	n := len(db.order)
+	if n > limit {
+		n = limit
+	}
+	if n > db.cfg.BatchSize {
+		n = db.cfg.BatchSize
+	}
+	if n == 0 {
+		return nil, nil
+	}
+
+	keys := db.order[:n:n]
+	db.order = db.order[n:]
+	batch := make(map[string]*entry, n)
+	for _, k := range keys {
+		batch[k] = db.pending[k]
+		db.inflight[k] = db.pending[k]
+		delete(db.pending, k)
+	}
+
+	close(db.drained)
+	db.drained = make(chan struct{})
+	return keys, batch
+}
+
+// finish clears in-flight changes that have been written, leaving any replaced by a later flush.
+func (db *WriteBehind) finish(batch map[string]*entry) {
+	db.Lock()
+	defer db.Unlock()
+	for k, e := range batch {
+		if db.inflight[k] == e {
+			delete(db.inflight, k)
+		}
+	}
+}
+
+// write writes a batch of changes to the database, returning the error for each key that failed.
+func (db *WriteBehind) write(ctx context.Context, keys []string, batch map[string]*entry) map[string]error {
+	failed := make(map[string]error)
+
+	// Use native batch operations when available, a failure is reported for every key in the batch
+	if _, ok := db.data.(hord.BatchDatabase); ok {
+		items := make(map[string][]byte)
+		var deletes []string
+		for _, k := range keys {
+			if batch[k].deleted {
+				deletes = append(deletes, k)
+				continue
+			}
+			items[k] = batch[k].data
+		}
+
+		// Batch operations do not accept a context, check it before each one
+		if len(items) > 0 {
+			err := ctx.Err()
+			if err == nil {
+				err = hord.SetMany(db.data, items)
+			}
+			if err != nil {
+				for k := range items {
+					failed[k] = err
+				}
+			}
+		}
+		if len(deletes) > 0 {
+			err := ctx.Err()
+			if err == nil {
+				err = hord.DeleteMany(db.data, deletes)
+			}
+			if err != nil {
+				for _, k := range deletes {
+					failed[k] = err
+				}
+			}
+		}
+		return failed
+	}
+
+	data := hord.WrapContext(db.data)
+	for _, k := range keys {
+		var err error
+		if batch[k].deleted {
+			err = data.DeleteContext(ctx, k)
+			if errors.Is(err, hord.ErrNil) {
+				err = nil
+			}
+		} else {
+			err = data.SetContext(ctx, k, batch[k].data)
+		}
+		if err != nil {
+			failed[k] = err
+		}
+	}
+	return failed
+}
+
+// Flush writes the changes pending when it is called to the database. Changes queued while flushing are left for a
+// later flush. An error is returned if any change failed to be written.
+func (db *WriteBehind) Flush() error {
+	return db.FlushContext(context.Background())
+}
+
+// FlushContext is a context-aware version of Flush.
+func (db *WriteBehind) FlushContext(ctx context.Context) error {
+	if db == nil || db.data == nil || db.cache == nil {
+		return hord.ErrNoDial
+	}
+
+	db.flushing.Lock()
+	defer db.flushing.Unlock()
+
+	// Only drain the keys queued when the flush started, so continuous writes cannot keep a flush running forever.
+	// Keys are taken from the front of the queue and new keys are appended, so these are the first keys taken.
+	remaining := db.queued()
+
+	var errs []error
+	for remaining > 0 && ctx.Err() == nil {
+		keys, batch := db.take(remaining)
+		if len(keys) == 0 {
+			break
+		}
+		remaining -= len(keys)
+
+		failed := db.write(ctx, keys, batch)
+		db.finish(batch)
+
+		for _, k := range keys {
+			err, ok := failed[k]
+			if !ok {
+				continue
+			}
+			errs = append(errs, fmt.Errorf("unable to write %q - %w", k, err))
+			if db.cfg.OnError != nil {
+				db.cfg.OnError(k, err)
+			}
+		}
+	}
+
+	if ctx.Err() != nil {
+		errs = append(errs, ctx.Err())
+	}
+	return errors.Join(errs...)
+}
+
+// Setup will run the Setup function for both the database and the cache.
+func (db *WriteBehind) Setup() error {
+	return db.SetupContext(context.Background())
+}
+
+// SetupContext is a context-aware version of Setup.
+func (db *WriteBehind) SetupContext(ctx context.Context) error {
+	if db == nil || db.data == nil || db.cache == nil {
+		return hord.ErrNoDial
+	}
+
+	if err := hord.WrapContext(db.data).SetupContext(ctx); err != nil {
+		return err
+	}
+
+	if err := hord.WrapContext(db.cache).SetupContext(ctx); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// HealthCheck will run the HealthCheck function for both the database and the cache.
+func (db *WriteBehind) HealthCheck() error {
+	return db.HealthCheckContext(context.Background())
+}
+
+// HealthCheckContext is a context-aware version of HealthCheck.
+func (db *WriteBehind) HealthCheckContext(ctx context.Context) error {
+	if db == nil || db.data == nil || db.cache == nil {
+		return hord.ErrNoDial
+	}
+
+	dataErr := hord.WrapContext(db.data).HealthCheckContext(ctx)
+	cacheErr := hord.WrapContext(db.cache).HealthCheckContext(ctx)
+
+	if dataErr != nil {
+		return dataErr
+	} else if cacheErr != nil {
+		return cacheErr
+	}
+
+	return nil
+}
+
+// Get will return pending writes first, then check the cache database. If not found, the data is fetched from the
+// data database and stored in the cache.
+func (db *WriteBehind) Get(key string) ([]byte, error) {
+	return db.GetContext(context.Background(), key)
+}
+
+// GetContext is a context-aware version of Get.
+func (db *WriteBehind) GetContext(ctx context.Context, key string) ([]byte, error) {
+	if db == nil || db.data == nil || db.cache == nil {
+		return nil, hord.ErrNoDial
+	}
+
+	if e, ok := db.lookup(key); ok {
+		if e.deleted {
+			return nil, hord.ErrNil
+		}
+		return e.data, nil
+	}
+
+	// Check the cache
+	data, err := hord.WrapContext(db.cache).GetContext(ctx, key)
+	if (err != nil) && !errors.Is(err, hord.ErrNil) {
+		return nil, err
+	} else if !errors.Is(err, hord.ErrNil) {
+		return data, nil
+	}
+
+	// Fill the cache while holding the key lock so a concurrent write cannot be overwritten with older data
+	unlock := db.lock(key)
+	defer unlock()
+
+	if e, ok := db.lookup(key); ok {
+		if e.deleted {
+			return nil, hord.ErrNil
+		}
+		return e.data, nil
+	}
+
+	data, err = hord.WrapContext(db.data).GetContext(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	err = hord.WrapContext(db.cache).SetContext(ctx, key, data)
+	if err != nil {
+		return data, fmt.Errorf("%w: %w", hord.ErrCacheError, err)
+	}
+
+	return data, nil
+}
+
+// Set will set the data in the cache database and queue it to be written to the data database.
+func (db *WriteBehind) Set(key string, data []byte) error {
+	return db.SetContext(context.Background(), key, data)
+}
+
+// SetContext is a context-aware version of Set.
+func (db *WriteBehind) SetContext(ctx context.Context, key string, data []byte) error {
+	if db == nil || db.data == nil || db.cache == nil {
+		return hord.ErrNoDial
+	}
+
+	if err := hord.ValidKey(key); err != nil {
+		return err
+	}
+	if err := hord.ValidData(data); err != nil {
+		return err
+	}
+
+	if db.isClosed() {
+		return hord.ErrClosed
+	}
+
+	unlock := db.lock(key)
+	defer unlock()
+
+	err := hord.WrapContext(db.cache).SetContext(ctx, key, data)
+	if err != nil {
+		return fmt.Errorf("%w: %w", hord.ErrCacheError, err)
+	}
+
+	err = db.enqueue(ctx, key, &entry{data: data})
+	if err != nil {
+		// Invalidate the cache entry, the value will not be written to the database
+		_ = hord.WrapContext(db.cache).DeleteContext(context.Background(), key)
+		return err
+	}
+
+	return nil
+}
+
+// Delete will delete the data from the cache database and queue the delete for the data database.
+func (db *WriteBehind) Delete(key string) error {
+	return db.DeleteContext(context.Background(), key)
+}
+
+// DeleteContext is a context-aware version of Delete.
+func (db *WriteBehind) DeleteContext(ctx context.Context, key string) error {
+	if db == nil || db.data == nil || db.cache == nil {
+		return hord.ErrNoDial
+	}
+
+	if err := hord.ValidKey(key); err != nil {
+		return err
+	}
+
+	if db.isClosed() {
+		return hord.ErrClosed
+	}
+
+	unlock := db.lock(key)
+	defer unlock()
+
+	err := hord.WrapContext(db.cache).DeleteContext(ctx, key)
+	if err != nil {
+		return fmt.Errorf("%w: %w", hord.ErrCacheError, err)
+	}
+
+	return db.enqueue(ctx, key, &entry{deleted: true})
+}
+
+// Keys will return the keys from the data database, including pending writes.
+func (db *WriteBehind) Keys() ([]string, error) {
+	return db.KeysContext(context.Background())
+}
+
+// KeysContext is a context-aware version of Keys.
+func (db *WriteBehind) KeysContext(ctx context.Context) ([]string, error) {
+	if db == nil || db.data == nil || db.cache == nil {
+		return nil, hord.ErrNoDial
+	}
+
+	keys, err := hord.WrapContext(db.data).KeysContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	db.Lock()
+	defer db.Unlock()
+
+	// Apply in-flight changes, followed by pending changes which replace them
+	seen := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		seen[k] = true
+	}
+	for _, changes := range []map[string]*entry{db.inflight, db.pending} {
+		for k, e := range changes {
+			seen[k] = !e.deleted
+		}
+	}
+
+	keys = keys[:0]
+	for k, ok := range seen {
+		if ok {
+			keys = append(keys, k)
+		}
+	}
+	return keys, nil
+}
+
+// CacheKeys will return the keys from the cache database.
+func (db *WriteBehind) CacheKeys() ([]string, error) {
+	if db == nil || db.data == nil || db.cache == nil {
+		return nil, hord.ErrNoDial
+	}
+
+	return db.cache.Keys()
+}
+
+// Pending returns the number of keys waiting to be written to the data database.
+func (db *WriteBehind) Pending() int {
+	db.Lock()
+	defer db.Unlock()
+	return len(db.pending) + len(db.inflight)
+}
+
+// GetCache will return the cache database.
+func (db *WriteBehind) GetCache() hord.Database {
+	return db.cache
+}
+
+// GetDatabase will return the data database.
+func (db *WriteBehind) GetDatabase() hord.Database {
+	return db.data
+}
+
+// Capabilities returns the optional features supported by the write-behind cache.
+func (db *WriteBehind) Capabilities() hord.Capabilities {
+	return hord.Capabilities{Context: true}
+}
+
+// Close will stop the background flusher, write any pending changes to the data database, and close the connections
+// to both the database and the cache. Writes made after Close return hord.ErrClosed.
+func (db *WriteBehind) Close() {
+	if db == nil || db.data == nil || db.cache == nil {
+		return
+	}
+
+	db.closeOnce.Do(func() {
+		db.Lock()
+		db.closed = true
+		close(db.drained)
+		db.drained = make(chan struct{})
+		db.Unlock()
+
+		close(db.stop)
+		<-db.done
+		_ = db.Flush()
+
+		db.data.Close()
+		db.cache.Close()
+	})
+}
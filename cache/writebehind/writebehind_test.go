@@ -0,0 +1,527 @@
+package writebehind
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/madflojo/hord"
+	"github.com/madflojo/hord/drivers/hashmap"
+	"github.com/madflojo/hord/drivers/mock"
+)
+
+// Test Errors used for testing purposes
+var (
+	ErrDatabaseTest = errors.New("database error")
+	ErrCacheTest    = errors.New("cache error")
+)
+
+// setupCache is a helper function to create a new WriteBehind driver backed by hashmap databases.
+func setupCache(t *testing.T, cfg Config) (*WriteBehind, hord.Database, hord.Database) {
+	t.Helper()
+
+	data, err := hashmap.Dial(hashmap.Config{})
+	if err != nil {
+		t.Fatalf("Failed to create database - %s", err)
+	}
+	cache, err := hashmap.Dial(hashmap.Config{})
+	if err != nil {
+		t.Fatalf("Failed to create cache - %s", err)
+	}
+
+	if cfg.Database == nil {
+		cfg.Database = data
+	}
+	cfg.Cache = cache
+
+	db, err := Dial(cfg)
+	if err != nil {
+		t.Fatalf("Failed to connect to database - %s", err)
+	}
+	return db, data, cache
+}
+
+func TestDial(t *testing.T) {
+	unitTests := map[string]struct {
+		config        Config
+		expectedError error
+	}{
+		"No Config":   {config: Config{}, expectedError: hord.ErrInvalidDatabase},
+		"No Database": {config: Config{Cache: &mock.Database{}}, expectedError: hord.ErrInvalidDatabase},
+		"No Cache":    {config: Config{Database: &mock.Database{}}, expectedError: hord.ErrInvalidDatabase},
+		"Happy Path":  {config: Config{Database: &mock.Database{}, Cache: &mock.Database{}}, expectedError: nil},
+	}
+
+	for name, test := range unitTests {
+		t.Run(name, func(t *testing.T) {
+			db, err := Dial(test.config)
+			if !errors.Is(err, test.expectedError) {
+				t.Errorf("Dial(%v) returned error: %s, expected %s", test.config, err, test.expectedError)
+			}
+			if err == nil {
+				db.Close()
+			}
+		})
+	}
+}
+
+func TestWriteBehind(t *testing.T) {
+	db, data, cache := setupCache(t, Config{FlushInterval: time.Hour})
+	defer db.Close()
+
+	if err := db.Setup(); err != nil {
+		t.Fatalf("Setup() returned error: %s", err)
+	}
+	if err := db.HealthCheck(); err != nil {
+		t.Fatalf("HealthCheck() returned error: %s", err)
+	}
+
+	t.Run("Acknowledged Before Flush", func(t *testing.T) {
+		if err := db.Set("counter", []byte("1")); err != nil {
+			t.Fatalf("Set() returned error: %s", err)
+		}
+		if _, err := data.Get("counter"); !errors.Is(err, hord.ErrNil) {
+			t.Errorf("Expected database to not yet hold value, got %v", err)
+		}
+		if v, err := cache.Get("counter"); err != nil || string(v) != "1" {
+			t.Errorf("Expected cache to hold value, got %q - %v", v, err)
+		}
+		if v, err := db.Get("counter"); err != nil || string(v) != "1" {
+			t.Errorf("Get() returned %q - %v, expected pending value", v, err)
+		}
+	})
+
+	t.Run("Coalescing", func(t *testing.T) {
+		for i := 2; i <= 10; i++ {
+			if err := db.Set("counter", []byte(fmt.Sprint(i))); err != nil {
+				t.Fatalf("Set() returned error: %s", err)
+			}
+		}
+		if db.Pending() != 1 {
+			t.Errorf("Expected repeated writes to coalesce, %d pending", db.Pending())
+		}
+	})
+
+	t.Run("Keys Include Pending", func(t *testing.T) {
+		keys, err := db.Keys()
+		if err != nil || len(keys) != 1 || keys[0] != "counter" {
+			t.Errorf("Keys() returned %v - %v, expected pending key", keys, err)
+		}
+	})
+
+	t.Run("Flush", func(t *testing.T) {
+		if err := db.Flush(); err != nil {
+			t.Fatalf("Flush() returned error: %s", err)
+		}
+		if v, err := data.Get("counter"); err != nil || string(v) != "10" {
+			t.Errorf("Expected database to hold latest value, got %q - %v", v, err)
+		}
+		if db.Pending() != 0 {
+			t.Errorf("Expected no pending writes, %d pending", db.Pending())
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		if err := db.Delete("counter"); err != nil {
+			t.Fatalf("Delete() returned error: %s", err)
+		}
+		if _, err := db.Get("counter"); !errors.Is(err, hord.ErrNil) {
+			t.Errorf("Expected pending delete to hide value, got %v", err)
+		}
+		keys, err := db.Keys()
+		if err != nil || len(keys) != 0 {
+			t.Errorf("Keys() returned %v - %v, expected no keys", keys, err)
+		}
+		if err := db.Flush(); err != nil {
+			t.Fatalf("Flush() returned error: %s", err)
+		}
+		if _, err := data.Get("counter"); !errors.Is(err, hord.ErrNil) {
+			t.Errorf("Expected database value to be deleted, got %v", err)
+		}
+	})
+
+	t.Run("Cache Fill", func(t *testing.T) {
+		if err := data.Set("stored", []byte("value")); err != nil {
+			t.Fatalf("Unexpected error - %s", err)
+		}
+		if v, err := db.Get("stored"); err != nil || string(v) != "value" {
+			t.Errorf("Get() returned %q - %v", v, err)
+		}
+		if v, err := cache.Get("stored"); err != nil || string(v) != "value" {
+			t.Errorf("Expected cache to be filled, got %q - %v", v, err)
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		if err := db.Set("", []byte("value")); !errors.Is(err, hord.ErrInvalidKey) {
+			t.Errorf("Expected ErrInvalidKey, got %v", err)
+		}
+		if err := db.Set("key", nil); !errors.Is(err, hord.ErrInvalidData) {
+			t.Errorf("Expected ErrInvalidData, got %v", err)
+		}
+	})
+}
+
+func TestBackgroundFlush(t *testing.T) {
+	t.Run("Interval", func(t *testing.T) {
+		db, data, _ := setupCache(t, Config{FlushInterval: 10 * time.Millisecond})
+		defer db.Close()
+
+		if err := db.Set("key", []byte("value")); err != nil {
+			t.Fatalf("Set() returned error: %s", err)
+		}
+
+		deadline := time.Now().Add(5 * time.Second)
+		for time.Now().Before(deadline) {
+			if _, err := data.Get("key"); err == nil {
+				return
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+		t.Errorf("Expected value to be flushed within interval")
+	})
+
+	t.Run("Batch Size", func(t *testing.T) {
+		db, data, _ := setupCache(t, Config{FlushInterval: time.Hour, BatchSize: 5})
+		defer db.Close()
+
+		for i := 0; i < 5; i++ {
+			if err := db.Set(fmt.Sprint(i), []byte("value")); err != nil {
+				t.Fatalf("Set() returned error: %s", err)
+			}
+		}
+
+		deadline := time.Now().Add(5 * time.Second)
+		for time.Now().Before(deadline) {
+			if keys, _ := data.Keys(); len(keys) == 5 {
+				return
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+		t.Errorf("Expected a full batch to be flushed before the interval")
+	})
+}
+
+func TestBoundedQueue(t *testing.T) {
+	var release sync.Once
+	block := make(chan struct{})
+	data, err := mock.Dial(mock.Config{
+		SetFunc: func(key string, value []byte) error {
+			<-block
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error - %s", err)
+	}
+
+	db, _, _ := setupCache(t, Config{Database: data, FlushInterval: time.Hour, QueueSize: 2, BatchSize: 2})
+	defer db.Close()
+	defer release.Do(func() { close(block) })
+
+	// Fill the queue, the background flusher takes the batch and blocks on the database
+	for _, k := range []string{"a", "b"} {
+		if err := db.Set(k, []byte("value")); err != nil {
+			t.Fatalf("Set() returned error: %s", err)
+		}
+	}
+	for {
+		db.Lock()
+		n := len(db.inflight)
+		db.Unlock()
+		if n == 2 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	// Fill the queue again while the first batch is in-flight
+	for _, k := range []string{"c", "d"} {
+		if err := db.Set(k, []byte("value")); err != nil {
+			t.Fatalf("Set() returned error: %s", err)
+		}
+	}
+
+	// Writes to queued keys still coalesce while the queue is full
+	if err := db.Set("c", []byte("updated")); err != nil {
+		t.Fatalf("Set() returned error: %s", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := db.SetContext(ctx, "e", []byte("value")); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected full queue to block until the context is done, got %v", err)
+	}
+
+	release.Do(func() { close(block) })
+	if err := db.Set("e", []byte("value")); err != nil {
+		t.Errorf("Expected write to succeed once the queue drains, got %v", err)
+	}
+}
+
+func TestFlushErrors(t *testing.T) {
+	var failures int32
+	var mu sync.Mutex
+	var failed []string
+
+	data, err := mock.Dial(mock.Config{
+		SetFunc: func(key string, value []byte) error {
+			if key == "bad" {
+				return ErrDatabaseTest
+			}
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error - %s", err)
+	}
+
+	db, _, cache := setupCache(t, Config{
+		Database:      data,
+		FlushInterval: time.Hour,
+		OnError: func(key string, err error) {
+			atomic.AddInt32(&failures, 1)
+			if !errors.Is(err, ErrDatabaseTest) {
+				t.Errorf("OnError() called with %v, expected %s", err, ErrDatabaseTest)
+			}
+			mu.Lock()
+			failed = append(failed, key)
+			mu.Unlock()
+		},
+	})
+	defer db.Close()
+
+	for _, k := range []string{"good", "bad"} {
+		if err := db.Set(k, []byte("value")); err != nil {
+			t.Fatalf("Set() returned error: %s", err)
+		}
+	}
+
+	if err := db.Flush(); !errors.Is(err, ErrDatabaseTest) {
+		t.Errorf("Flush() returned error: %v, expected %s", err, ErrDatabaseTest)
+	}
+	if atomic.LoadInt32(&failures) != 1 || failed[0] != "bad" {
+		t.Errorf("Expected OnError to be called once for bad key, got %v", failed)
+	}
+	if v, err := cache.Get("bad"); err != nil || string(v) != "value" {
+		t.Errorf("Expected cache to retain failed value, got %q - %v", v, err)
+	}
+}
+
+// batchHook is a batch capable database that calls a hook before each SetMany.
+type batchHook struct {
+	*hashmap.Database
+	hook func()
+}
+
+func (b *batchHook) SetMany(items map[string][]byte) error {
+	b.hook()
+	return b.Database.SetMany(items)
+}
+
+func TestFlushContext(t *testing.T) {
+	data, err := hashmap.Dial(hashmap.Config{})
+	if err != nil {
+		t.Fatalf("Failed to create database - %s", err)
+	}
+	if err := data.Set("deleted", []byte("value")); err != nil {
+		t.Fatalf("Unexpected error - %s", err)
+	}
+
+	// Cancel the flush once the first batch operation is running
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	db, _, _ := setupCache(t, Config{
+		Database:      &batchHook{Database: data, hook: cancel},
+		FlushInterval: time.Hour,
+	})
+	defer db.Close()
+
+	if err := db.Set("key", []byte("value")); err != nil {
+		t.Fatalf("Set() returned error: %s", err)
+	}
+	if err := db.Delete("deleted"); err != nil {
+		t.Fatalf("Delete() returned error: %s", err)
+	}
+
+	if err := db.FlushContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("FlushContext() returned error: %v, expected %s", err, context.Canceled)
+	}
+	if _, err := data.Get("deleted"); err != nil {
+		t.Errorf("Expected batch delete to be skipped once the context was canceled, got %v", err)
+	}
+}
+
+func TestFlushSnapshot(t *testing.T) {
+	data, err := hashmap.Dial(hashmap.Config{})
+	if err != nil {
+		t.Fatalf("Failed to create database - %s", err)
+	}
+
+	// Every database write queues another key, as continuous writers would
+	var db *WriteBehind
+	var writes int32
+	db, _, _ = setupCache(t, Config{
+		Database: &batchHook{Database: data, hook: func() {
+			n := atomic.AddInt32(&writes, 1)
+			if n < 100 {
+				err := db.Set(fmt.Sprintf("key-%d", n), []byte("value"))
+				if err != nil && !errors.Is(err, hord.ErrClosed) {
+					t.Errorf("Set() returned error: %s", err)
+				}
+			}
+		}},
+		FlushInterval: time.Hour,
+		BatchSize:     1,
+	})
+	defer db.Close()
+
+	if err := db.Set("key", []byte("value")); err != nil {
+		t.Fatalf("Set() returned error: %s", err)
+	}
+	if err := db.Flush(); err != nil {
+		t.Fatalf("Flush() returned error: %s", err)
+	}
+
+	if n := atomic.LoadInt32(&writes); n != 1 {
+		t.Errorf("Expected Flush to only write keys queued when it was called, got %d writes", n)
+	}
+	if db.Pending() != 1 {
+		t.Errorf("Expected keys queued during Flush to remain pending, got %d", db.Pending())
+	}
+}
+
+func TestCacheErrors(t *testing.T) {
+	cache, err := mock.Dial(mock.Config{
+		SetFunc:    func(key string, value []byte) error { return ErrCacheTest },
+		DeleteFunc: func(key string) error { return ErrCacheTest },
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error - %s", err)
+	}
+	data, err := hashmap.Dial(hashmap.Config{})
+	if err != nil {
+		t.Fatalf("Unexpected error - %s", err)
+	}
+
+	db, err := Dial(Config{Database: data, Cache: cache, FlushInterval: time.Hour})
+	if err != nil {
+		t.Fatalf("Failed to connect to database - %s", err)
+	}
+	defer db.Close()
+
+	if err := db.Set("key", []byte("value")); !errors.Is(err, hord.ErrCacheError) {
+		t.Errorf("Set() returned error: %v, expected %s", err, hord.ErrCacheError)
+	}
+	if err := db.Delete("key"); !errors.Is(err, hord.ErrCacheError) {
+		t.Errorf("Delete() returned error: %v, expected %s", err, hord.ErrCacheError)
+	}
+	if db.Pending() != 0 {
+		t.Errorf("Expected failed cache writes to not be queued, %d pending", db.Pending())
+	}
+}
+
+func TestClose(t *testing.T) {
+	var mu sync.Mutex
+	var flushed []string
+	data, err := mock.Dial(mock.Config{
+		SetFunc: func(key string, value []byte) error {
+			mu.Lock()
+			defer mu.Unlock()
+			flushed = append(flushed, key)
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error - %s", err)
+	}
+
+	db, _, _ := setupCache(t, Config{Database: data, FlushInterval: time.Hour})
+
+	keys := []string{"a", "b", "c"}
+	for _, k := range keys {
+		if err := db.Set(k, []byte("value")); err != nil {
+			t.Fatalf("Set() returned error: %s", err)
+		}
+	}
+
+	db.Close()
+	db.Close()
+
+	mu.Lock()
+	sort.Strings(flushed)
+	if fmt.Sprint(flushed) != fmt.Sprint(keys) {
+		t.Errorf("Expected pending writes to drain on Close, database received %v", flushed)
+	}
+	mu.Unlock()
+
+	if err := db.Set("d", []byte("value")); !errors.Is(err, hord.ErrClosed) {
+		t.Errorf("Expected ErrClosed after Close, got %v", err)
+	}
+}
+
+func TestConcurrency(t *testing.T) {
+	db, data, _ := setupCache(t, Config{FlushInterval: time.Millisecond, BatchSize: 3, QueueSize: 5})
+	defer db.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				_ = db.Set(fmt.Sprint(j%7), []byte{byte(i), byte(j)})
+			}
+		}(i)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				_, _ = db.Get(fmt.Sprint(j % 7))
+			}
+		}()
+	}
+	wg.Wait()
+
+	if err := db.Flush(); err != nil {
+		t.Fatalf("Flush() returned error: %s", err)
+	}
+	for j := 0; j < 7; j++ {
+		a, _ := data.Get(fmt.Sprint(j))
+		b, _ := db.Get(fmt.Sprint(j))
+		if !bytes.Equal(a, b) {
+			t.Errorf("Key %d: database %v is inconsistent with cache %v", j, a, b)
+		}
+	}
+}
+
+func TestNilDatabase(t *testing.T) {
+	var db *WriteBehind
+	if err := db.Setup(); !errors.Is(err, hord.ErrNoDial) {
+		t.Errorf("Setup() returned error: %s, expected %s", err, hord.ErrNoDial)
+	}
+	if err := db.HealthCheck(); !errors.Is(err, hord.ErrNoDial) {
+		t.Errorf("HealthCheck() returned error: %s, expected %s", err, hord.ErrNoDial)
+	}
+	if _, err := db.Get("key"); !errors.Is(err, hord.ErrNoDial) {
+		t.Errorf("Get() returned error: %s, expected %s", err, hord.ErrNoDial)
+	}
+	if err := db.Set("key", []byte("data")); !errors.Is(err, hord.ErrNoDial) {
+		t.Errorf("Set() returned error: %s, expected %s", err, hord.ErrNoDial)
+	}
+	if err := db.Delete("key"); !errors.Is(err, hord.ErrNoDial) {
+		t.Errorf("Delete() returned error: %s, expected %s", err, hord.ErrNoDial)
+	}
+	if _, err := db.Keys(); !errors.Is(err, hord.ErrNoDial) {
+		t.Errorf("Keys() returned error: %s, expected %s", err, hord.ErrNoDial)
+	}
+	if err := db.Flush(); !errors.Is(err, hord.ErrNoDial) {
+		t.Errorf("Flush() returned error: %s, expected %s", err, hord.ErrNoDial)
+	}
+	db.Close()
+}
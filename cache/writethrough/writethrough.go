@@ -0,0 +1,281 @@
+/*
+Package writethrough provides a Hord database driver for a write-through cache. Writes update the database and the
+cache as a single unit, keeping the cache consistent with the database. To use this driver, import it as follows:
+
+	import (
+	    "github.com/madflojo/hord"
+	    "github.com/madflojo/hord/cache/writethrough"
+	)
+
+# Connecting to the Database
+
+Use the Dial() function to create a new client for interacting with the cache.
+
+	// Handle database connection
+	var database hord.Database
+	...
+
+	// Handle cache connection
+	var cache hord.Database
+	...
+
+	var db hord.Database
+	db, err := writethrough.Dial(writethrough.Config{
+		Database: database,
+		Cache: 	  cache,
+	})
+	if err != nil {
+	    // Handle connection error
+	}
+
+# Initialize database
+
+Hord provides a Setup() function for preparing a database. This function is safe to execute after every Dial().
+
+	err := db.Setup()
+	if err != nil {
+	    // Handle setup error
+	}
+
+# Consistency
+
+Set writes the database and then the cache, so readers never see a value the database has not accepted. If the
+cache write fails, the cache entry is invalidated so that the previous value is not served once Set returns. Delete
+invalidates the cache before the database, so a failed database delete can never leave a deleted value cached.
+
+Writes and cache fills for the same key are serialized within a single client. Cache misses are filled from the
+database, as with a look-aside cache.
+*/
+package writethrough
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"sync"
+
+	"github.com/madflojo/hord"
+)
+
+// stripes is the number of locks used to serialize operations by key.
+const stripes = 64
+
+// Config provides the configuration options for the WriteThrough driver.
+type Config struct {
+	Database hord.Database
+	Cache    hord.Database
+}
+
+// WriteThrough is used to store data in a write-through caching pattern. It also satisfies the Hord database
+// interface.
+type WriteThrough struct {
+	data  hord.Database
+	cache hord.Database
+
+	// locks serialize writes and cache fills for keys sharing a stripe
+	locks [stripes]sync.Mutex
+}
+
+func Dial(cfg Config) (*WriteThrough, error) {
+	if (cfg.Database == nil) || (cfg.Cache == nil) {
+		return nil, hord.ErrInvalidDatabase
+	}
+
+	return &WriteThrough{
+		data:  cfg.Database,
+		cache: cfg.Cache,
+	}, nil
+}
+
+// lock locks the stripe for key and returns the function to unlock it.
+func (db *WriteThrough) lock(key string) func() {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	m := &db.locks[h.Sum32()%stripes]
+	m.Lock()
+	return m.Unlock
+}
+
+// Setup will run the Setup function for both the database and the cache.
+func (db *WriteThrough) Setup() error {
+	return db.SetupContext(context.Background())
+}
+
+// SetupContext is a context-aware version of Setup.
+func (db *WriteThrough) SetupContext(ctx context.Context) error {
+	if db == nil || db.data == nil || db.cache == nil {
+		return hord.ErrNoDial
+	}
+
+	if err := hord.WrapContext(db.data).SetupContext(ctx); err != nil {
+		return err
+	}
+
+	if err := hord.WrapContext(db.cache).SetupContext(ctx); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// HealthCheck will run the HealthCheck function for both the database and the cache.
+func (db *WriteThrough) HealthCheck() error {
+	return db.HealthCheckContext(context.Background())
+}
+
+// HealthCheckContext is a context-aware version of HealthCheck.
+func (db *WriteThrough) HealthCheckContext(ctx context.Context) error {
+	if db == nil || db.data == nil || db.cache == nil {
+		return hord.ErrNoDial
+	}
+
+	dataErr := hord.WrapContext(db.data).HealthCheckContext(ctx)
+	cacheErr := hord.WrapContext(db.cache).HealthCheckContext(ctx)
+
+	if dataErr != nil {
+		return dataErr
+	} else if cacheErr != nil {
+		return cacheErr
+	}
+
+	return nil
+}
+
+// Get will get the data from the cache database. If not found, the data is fetched from the data database and stored
+// in the cache.
+func (db *WriteThrough) Get(key string) ([]byte, error) {
+	return db.GetContext(context.Background(), key)
+}
+
+// GetContext is a context-aware version of Get.
+func (db *WriteThrough) GetContext(ctx context.Context, key string) ([]byte, error) {
+	if db == nil || db.data == nil || db.cache == nil {
+		return nil, hord.ErrNoDial
+	}
+
+	// Check the cache first
+	data, err := hord.WrapContext(db.cache).GetContext(ctx, key)
+	if (err != nil) && !errors.Is(err, hord.ErrNil) {
+		return nil, err
+	} else if !errors.Is(err, hord.ErrNil) {
+		return data, nil
+	}
+
+	// Fill the cache while holding the key lock so a concurrent Set cannot be overwritten with older data
+	unlock := db.lock(key)
+	defer unlock()
+
+	data, err = hord.WrapContext(db.data).GetContext(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	err = hord.WrapContext(db.cache).SetContext(ctx, key, data)
+	if err != nil {
+		return data, fmt.Errorf("%w: %w", hord.ErrCacheError, err)
+	}
+
+	return data, nil
+}
+
+// Set will set the data in the data database and then the cache. If the cache fails, the cache entry is invalidated.
+func (db *WriteThrough) Set(key string, data []byte) error {
+	return db.SetContext(context.Background(), key, data)
+}
+
+// SetContext is a context-aware version of Set.
+func (db *WriteThrough) SetContext(ctx context.Context, key string, data []byte) error {
+	if db == nil || db.data == nil || db.cache == nil {
+		return hord.ErrNoDial
+	}
+
+	unlock := db.lock(key)
+	defer unlock()
+
+	err := hord.WrapContext(db.data).SetContext(ctx, key, data)
+	if err != nil {
+		return err
+	}
+
+	// Update cache only if database Set was successful
+	err = hord.WrapContext(db.cache).SetContext(ctx, key, data)
+	if err != nil {
+		// Invalidate the cache entry, it holds the previous value. The original context may already be done.
+		if delErr := hord.WrapContext(db.cache).DeleteContext(context.Background(), key); delErr != nil {
+			return fmt.Errorf("%w: %w: unable to invalidate cache - %w", hord.ErrCacheError, err, delErr)
+		}
+		return fmt.Errorf("%w: %w", hord.ErrCacheError, err)
+	}
+
+	return nil
+}
+
+// Delete will delete the data from the cache and then the data database.
+func (db *WriteThrough) Delete(key string) error {
+	return db.DeleteContext(context.Background(), key)
+}
+
+// DeleteContext is a context-aware version of Delete.
+func (db *WriteThrough) DeleteContext(ctx context.Context, key string) error {
+	if db == nil || db.data == nil || db.cache == nil {
+		return hord.ErrNoDial
+	}
+
+	unlock := db.lock(key)
+	defer unlock()
+
+	// Invalidate the cache before the database, a failure here leaves both untouched
+	err := hord.WrapContext(db.cache).DeleteContext(ctx, key)
+	if err != nil {
+		return fmt.Errorf("%w: %w", hord.ErrCacheError, err)
+	}
+
+	return hord.WrapContext(db.data).DeleteContext(ctx, key)
+}
+
+// Keys will return the keys from the data database.
+func (db *WriteThrough) Keys() ([]string, error) {
+	return db.KeysContext(context.Background())
+}
+
+// KeysContext is a context-aware version of Keys.
+func (db *WriteThrough) KeysContext(ctx context.Context) ([]string, error) {
+	if db == nil || db.data == nil || db.cache == nil {
+		return nil, hord.ErrNoDial
+	}
+
+	return hord.WrapContext(db.data).KeysContext(ctx)
+}
+
+// CacheKeys will return the keys from the cache database.
+func (db *WriteThrough) CacheKeys() ([]string, error) {
+	if db == nil || db.data == nil || db.cache == nil {
+		return nil, hord.ErrNoDial
+	}
+
+	return db.cache.Keys()
+}
+
+// GetCache will return the cache database.
+func (db *WriteThrough) GetCache() hord.Database {
+	return db.cache
+}
+
+// GetDatabase will return the data database.
+func (db *WriteThrough) GetDatabase() hord.Database {
+	return db.data
+}
+
+// Capabilities returns the optional features supported by the write-through cache.
+func (db *WriteThrough) Capabilities() hord.Capabilities {
+	return hord.Capabilities{Context: true}
+}
+
+// Close will close the connections to both the database and the cache.
+func (db *WriteThrough) Close() {
+	if db != nil && db.data != nil && db.cache != nil {
+		db.data.Close()
+		db.cache.Close()
+	}
+}
@@ -0,0 +1,331 @@
+package writethrough
+
+import (
+	"bytes"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/madflojo/hord"
+	"github.com/madflojo/hord/drivers/hashmap"
+	"github.com/madflojo/hord/drivers/mock"
+)
+
+// Test Errors used for testing purposes
+var (
+	ErrDatabaseTest = errors.New("database error")
+	ErrCacheTest    = errors.New("cache error")
+)
+
+// recorder records the order of calls made to the mock databases.
+type recorder struct {
+	sync.Mutex
+	calls []string
+}
+
+func (r *recorder) record(call string) {
+	r.Lock()
+	defer r.Unlock()
+	r.calls = append(r.calls, call)
+}
+
+func (r *recorder) String() string {
+	r.Lock()
+	defer r.Unlock()
+	var b bytes.Buffer
+	for i, c := range r.calls {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		b.WriteString(c)
+	}
+	return b.String()
+}
+
+// setupCache is a helper function to create a new WriteThrough driver that records calls and returns the provided
+// errors.
+func setupCache(rec *recorder, cacheSetErr, cacheDeleteErr, dataSetErr, dataDeleteErr error) (*WriteThrough, error) {
+	cache, err := mock.Dial(mock.Config{
+		GetFunc: func(key string) ([]byte, error) {
+			rec.record("cache.get")
+			if key == "cache-hit" {
+				return []byte("cache-data"), nil
+			}
+			return nil, hord.ErrNil
+		},
+		SetFunc: func(key string, data []byte) error {
+			rec.record("cache.set")
+			return cacheSetErr
+		},
+		DeleteFunc: func(key string) error {
+			rec.record("cache.delete")
+			return cacheDeleteErr
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	database, err := mock.Dial(mock.Config{
+		GetFunc: func(key string) ([]byte, error) {
+			rec.record("data.get")
+			return []byte("database-data"), nil
+		},
+		SetFunc: func(key string, data []byte) error {
+			rec.record("data.set")
+			return dataSetErr
+		},
+		DeleteFunc: func(key string) error {
+			rec.record("data.delete")
+			return dataDeleteErr
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return Dial(Config{Database: database, Cache: cache})
+}
+
+func TestDial(t *testing.T) {
+	unitTests := map[string]struct {
+		config        Config
+		expectedError error
+	}{
+		"No Config":   {config: Config{}, expectedError: hord.ErrInvalidDatabase},
+		"No Database": {config: Config{Cache: &mock.Database{}}, expectedError: hord.ErrInvalidDatabase},
+		"No Cache":    {config: Config{Database: &mock.Database{}}, expectedError: hord.ErrInvalidDatabase},
+		"Happy Path":  {config: Config{Database: &mock.Database{}, Cache: &mock.Database{}}, expectedError: nil},
+	}
+
+	for name, test := range unitTests {
+		t.Run(name, func(t *testing.T) {
+			_, err := Dial(test.config)
+			if !errors.Is(err, test.expectedError) {
+				t.Errorf("Dial(%v) returned error: %s, expected %s", test.config, err, test.expectedError)
+			}
+		})
+	}
+}
+
+func TestGet(t *testing.T) {
+	unitTests := map[string]struct {
+		key           string
+		cacheSetErr   error
+		expectedData  []byte
+		expectedError error
+		expectedCalls string
+	}{
+		"Cache Hit": {
+			key:           "cache-hit",
+			expectedData:  []byte("cache-data"),
+			expectedCalls: "cache.get",
+		},
+		"Cache Miss": {
+			key:           "cache-miss",
+			expectedData:  []byte("database-data"),
+			expectedCalls: "cache.get,data.get,cache.set",
+		},
+		"Cache Fill Error": {
+			key:           "cache-miss",
+			cacheSetErr:   ErrCacheTest,
+			expectedData:  []byte("database-data"),
+			expectedError: hord.ErrCacheError,
+			expectedCalls: "cache.get,data.get,cache.set",
+		},
+	}
+
+	for name, test := range unitTests {
+		t.Run(name, func(t *testing.T) {
+			rec := &recorder{}
+			db, err := setupCache(rec, test.cacheSetErr, nil, nil, nil)
+			if err != nil {
+				t.Fatalf("Failed to connect to database - %s", err)
+			}
+
+			data, err := db.Get(test.key)
+			if !errors.Is(err, test.expectedError) {
+				t.Errorf("Get() returned error: %s, expected %s", err, test.expectedError)
+			}
+			if !bytes.Equal(data, test.expectedData) {
+				t.Errorf("Get() returned %q, expected %q", data, test.expectedData)
+			}
+			if rec.String() != test.expectedCalls {
+				t.Errorf("Get() made calls %q, expected %q", rec, test.expectedCalls)
+			}
+		})
+	}
+}
+
+func TestSet(t *testing.T) {
+	unitTests := map[string]struct {
+		cacheSetErr    error
+		cacheDeleteErr error
+		dataSetErr     error
+		expectedErrors []error
+		expectedCalls  string
+	}{
+		"Happy Path": {
+			expectedCalls: "data.set,cache.set",
+		},
+		"Database Error": {
+			dataSetErr:     ErrDatabaseTest,
+			expectedErrors: []error{ErrDatabaseTest},
+			expectedCalls:  "data.set",
+		},
+		"Cache Error": {
+			cacheSetErr:    ErrCacheTest,
+			expectedErrors: []error{hord.ErrCacheError, ErrCacheTest},
+			expectedCalls:  "data.set,cache.set,cache.delete",
+		},
+		"Invalidation Error": {
+			cacheSetErr:    ErrCacheTest,
+			cacheDeleteErr: ErrCacheTest,
+			expectedErrors: []error{hord.ErrCacheError, ErrCacheTest},
+			expectedCalls:  "data.set,cache.set,cache.delete",
+		},
+	}
+
+	for name, test := range unitTests {
+		t.Run(name, func(t *testing.T) {
+			rec := &recorder{}
+			db, err := setupCache(rec, test.cacheSetErr, test.cacheDeleteErr, test.dataSetErr, nil)
+			if err != nil {
+				t.Fatalf("Failed to connect to database - %s", err)
+			}
+
+			err = db.Set("key", []byte("data"))
+			if len(test.expectedErrors) == 0 && err != nil {
+				t.Errorf("Set() returned unexpected error: %s", err)
+			}
+			for _, e := range test.expectedErrors {
+				if !errors.Is(err, e) {
+					t.Errorf("Set() returned error: %s, expected %s", err, e)
+				}
+			}
+			if rec.String() != test.expectedCalls {
+				t.Errorf("Set() made calls %q, expected %q", rec, test.expectedCalls)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	unitTests := map[string]struct {
+		cacheDeleteErr error
+		dataDeleteErr  error
+		expectedError  error
+		expectedCalls  string
+	}{
+		"Happy Path": {
+			expectedCalls: "cache.delete,data.delete",
+		},
+		"Cache Error": {
+			cacheDeleteErr: ErrCacheTest,
+			expectedError:  hord.ErrCacheError,
+			expectedCalls:  "cache.delete",
+		},
+		"Database Error": {
+			dataDeleteErr: ErrDatabaseTest,
+			expectedError: ErrDatabaseTest,
+			expectedCalls: "cache.delete,data.delete",
+		},
+	}
+
+	for name, test := range unitTests {
+		t.Run(name, func(t *testing.T) {
+			rec := &recorder{}
+			db, err := setupCache(rec, nil, test.cacheDeleteErr, nil, test.dataDeleteErr)
+			if err != nil {
+				t.Fatalf("Failed to connect to database - %s", err)
+			}
+
+			err = db.Delete("key")
+			if !errors.Is(err, test.expectedError) {
+				t.Errorf("Delete() returned error: %s, expected %s", err, test.expectedError)
+			}
+			if rec.String() != test.expectedCalls {
+				t.Errorf("Delete() made calls %q, expected %q", rec, test.expectedCalls)
+			}
+		})
+	}
+}
+
+func TestConsistency(t *testing.T) {
+	data, err := hashmap.Dial(hashmap.Config{})
+	if err != nil {
+		t.Fatalf("Failed to create database - %s", err)
+	}
+	cache, err := hashmap.Dial(hashmap.Config{})
+	if err != nil {
+		t.Fatalf("Failed to create cache - %s", err)
+	}
+
+	db, err := Dial(Config{Database: data, Cache: cache})
+	if err != nil {
+		t.Fatalf("Failed to connect to database - %s", err)
+	}
+	defer db.Close()
+
+	if err := db.Setup(); err != nil {
+		t.Fatalf("Setup() returned error: %s", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			_ = db.Set("key", []byte{byte(i)})
+		}(i)
+		go func() {
+			defer wg.Done()
+			_, _ = db.Get("key")
+		}()
+	}
+	wg.Wait()
+
+	a, _ := data.Get("key")
+	b, _ := cache.Get("key")
+	if !bytes.Equal(a, b) {
+		t.Errorf("Cache %v is inconsistent with database %v", b, a)
+	}
+
+	if err := db.Delete("key"); err != nil {
+		t.Fatalf("Delete() returned error: %s", err)
+	}
+	if _, err := cache.Get("key"); !errors.Is(err, hord.ErrNil) {
+		t.Errorf("Expected cache entry to be removed, got %v", err)
+	}
+
+	keys, err := db.Keys()
+	if err != nil || len(keys) != 0 {
+		t.Errorf("Keys() returned %v - %v, expected no keys", keys, err)
+	}
+}
+
+func TestNilDatabase(t *testing.T) {
+	var db *WriteThrough
+	if err := db.Setup(); !errors.Is(err, hord.ErrNoDial) {
+		t.Errorf("Setup() returned error: %s, expected %s", err, hord.ErrNoDial)
+	}
+	if err := db.HealthCheck(); !errors.Is(err, hord.ErrNoDial) {
+		t.Errorf("HealthCheck() returned error: %s, expected %s", err, hord.ErrNoDial)
+	}
+	if _, err := db.Get("key"); !errors.Is(err, hord.ErrNoDial) {
+		t.Errorf("Get() returned error: %s, expected %s", err, hord.ErrNoDial)
+	}
+	if err := db.Set("key", []byte("data")); !errors.Is(err, hord.ErrNoDial) {
+		t.Errorf("Set() returned error: %s, expected %s", err, hord.ErrNoDial)
+	}
+	if err := db.Delete("key"); !errors.Is(err, hord.ErrNoDial) {
+		t.Errorf("Delete() returned error: %s, expected %s", err, hord.ErrNoDial)
+	}
+	if _, err := db.Keys(); !errors.Is(err, hord.ErrNoDial) {
+		t.Errorf("Keys() returned error: %s, expected %s", err, hord.ErrNoDial)
+	}
+	if _, err := db.CacheKeys(); !errors.Is(err, hord.ErrNoDial) {
+		t.Errorf("CacheKeys() returned error: %s, expected %s", err, hord.ErrNoDial)
+	}
+	db.Close()
+}
@@ -0,0 +1,69 @@
+package hord
+
+// Capabilities describes the optional features supported by a Database. Features vary by backend; use Capabilities to
+// choose strategies at runtime without type assertions on concrete driver types.
+type Capabilities struct {
+	// Context indicates the Database implements ContextDatabase.
+	Context bool
+
+	// TTL indicates the Database implements TTLDatabase and supports per-key expirations.
+	TTL bool
+
+	// Batch indicates the Database implements BatchDatabase with native multi-key operations.
+	Batch bool
+
+	// Iterate indicates the Database implements IterableDatabase with native streaming key iteration.
+	Iterate bool
+
+	// Prefix indicates the Database implements PrefixDatabase.
+	Prefix bool
+
+	// Range indicates the Database stores keys in sorted order and implements RangeDatabase.
+	Range bool
+
+	// CompareAndSwap indicates the Database implements VersionedDatabase.
+	CompareAndSwap bool
+
+	// Create indicates the Database implements CreateDatabase.
+	Create bool
+
+	// Watch indicates the Database implements WatchDatabase.
+	Watch bool
+
+	// Txn indicates the Database implements TxnDatabase and supports atomic multi-key writes.
+	Txn bool
+}
+
+// CapableDatabase is an optional interface implemented by drivers that report their supported features.
+type CapableDatabase interface {
+	Database
+
+	// Capabilities returns the optional features supported by the Database.
+	Capabilities() Capabilities
+}
+
+// CapabilitiesOf returns the Capabilities of the provided Database. If the Database implements CapableDatabase, the
+// reported Capabilities are returned; otherwise, Capabilities are detected from the optional interfaces the Database
+// implements. Drivers can only partially support an interface, so reported Capabilities should be preferred.
+func CapabilitiesOf(db Database) Capabilities {
+	if db == nil {
+		return Capabilities{}
+	}
+
+	if cdb, ok := db.(CapableDatabase); ok {
+		return cdb.Capabilities()
+	}
+
+	var c Capabilities
+	_, c.Context = db.(ContextDatabase)
+	_, c.TTL = db.(TTLDatabase)
+	_, c.Batch = db.(BatchDatabase)
+	_, c.Iterate = db.(IterableDatabase)
+	_, c.Prefix = db.(PrefixDatabase)
+	_, c.Range = db.(RangeDatabase)
+	_, c.CompareAndSwap = db.(VersionedDatabase)
+	_, c.Create = db.(CreateDatabase)
+	_, c.Watch = db.(WatchDatabase)
+	_, c.Txn = db.(TxnDatabase)
+	return c
+}
@@ -0,0 +1,36 @@
+package hord
+
+import (
+	"testing"
+)
+
+// capableDatabase is a mapDatabase that reports its own Capabilities.
+type capableDatabase struct {
+	mapDatabase
+}
+
+func (c *capableDatabase) Capabilities() Capabilities {
+	return Capabilities{Context: true, Watch: true}
+}
+
+func TestCapabilitiesOf(t *testing.T) {
+	t.Run("Nil Database", func(t *testing.T) {
+		if c := CapabilitiesOf(nil); c != (Capabilities{}) {
+			t.Errorf("Expected no capabilities, got %+v", c)
+		}
+	})
+
+	t.Run("Detected", func(t *testing.T) {
+		c := CapabilitiesOf(&txnDatabase{mapDatabase{data: map[string][]byte{}}})
+		if c != (Capabilities{Txn: true}) {
+			t.Errorf("Expected only Txn capability, got %+v", c)
+		}
+	})
+
+	t.Run("Reported", func(t *testing.T) {
+		c := CapabilitiesOf(&capableDatabase{mapDatabase{data: map[string][]byte{}}})
+		if c != (Capabilities{Context: true, Watch: true}) {
+			t.Errorf("Expected reported capabilities, got %+v", c)
+		}
+	})
+}
@@ -0,0 +1,235 @@
+/*
+Package compress provides a Hord database wrapper that transparently compresses values before they are stored and
+decompresses them when read. Gzip, Zstandard, and Snappy are supported. To use this package, import it as follows:
+
+	import (
+	    "github.com/madflojo/hord"
+	    "github.com/madflojo/hord/compress"
+	)
+
+# Wrapping a Database
+
+Use the Wrap() function to add compression to an existing database.
+
+	// Handle database connection
+	var database hord.Database
+	...
+
+	db, err := compress.Wrap(database, compress.Config{
+		Algorithm: compress.Zstd,
+		Threshold: 512,
+	})
+	if err != nil {
+	    // Handle configuration error
+	}
+
+# Value Format
+
+Each stored value starts with a single header byte identifying how the rest of the value is encoded. Values smaller
+than Threshold, or values that do not shrink when compressed, are stored raw behind a header byte. Values are always
+decoded based on their header, allowing the Algorithm to be changed without rewriting existing data.
+
+Values without a recognized header, such as values written before compression was enabled, are returned as-is. Raw
+values beginning with a byte between 0x00 and 0x03 cannot be distinguished from encoded values and should be rewritten
+through the wrapper.
+
+The Interceptor() function returns compression as a hord.Interceptor, allowing it to be combined with other
+interceptors using hord.Wrap(). Compression should run before interceptors that encrypt values.
+*/
+package compress
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+	"github.com/madflojo/hord"
+)
+
+// Algorithm is the compression algorithm used for new values. Algorithm values double as the header byte of values
+// compressed with them.
+type Algorithm byte
+
+const (
+	// raw is the header byte of values stored without compression.
+	raw Algorithm = iota
+
+	// Gzip compresses values using gzip.
+	Gzip
+
+	// Zstd compresses values using Zstandard.
+	Zstd
+
+	// Snappy compresses values using Snappy.
+	Snappy
+)
+
+// DefaultThreshold is the Threshold used when Config.Threshold is not set.
+const DefaultThreshold = 256
+
+var (
+	// ErrInvalidAlgorithm is returned when the configured Algorithm is not supported.
+	ErrInvalidAlgorithm = errors.New("invalid compression Algorithm")
+)
+
+// Config provides the configuration options for the compression wrapper.
+type Config struct {
+	// Algorithm is used to compress new values. Defaults to Gzip.
+	Algorithm Algorithm
+
+	// Threshold is the size in bytes below which values are stored without compression. Defaults to DefaultThreshold.
+	Threshold int
+}
+
+// Wrap returns a Database that compresses values stored within db.
+func Wrap(db hord.Database, cfg Config) (hord.Database, error) {
+	ic, err := Interceptor(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return hord.Wrap(db, ic), nil
+}
+
+// Interceptor returns a hord.Interceptor that compresses values on Set and SetWithTTL operations and decompresses
+// values returned from Get operations.
+func Interceptor(cfg Config) (hord.Interceptor, error) {
+	if cfg.Algorithm == raw {
+		cfg.Algorithm = Gzip
+	}
+	if cfg.Algorithm > Snappy {
+		return nil, ErrInvalidAlgorithm
+	}
+	if cfg.Threshold <= 0 {
+		cfg.Threshold = DefaultThreshold
+	}
+
+	c := &codec{cfg: cfg}
+	return func(ctx context.Context, call hord.Call, next hord.Handler) (hord.Result, error) {
+		switch call.Op {
+		case hord.OpSet, hord.OpSetWithTTL:
+			if err := hord.ValidData(call.Value); err != nil {
+				return hord.Result{}, err
+			}
+			v, err := c.encode(call.Value)
+			if err != nil {
+				return hord.Result{}, err
+			}
+			call.Value = v
+			return next(ctx, call)
+		case hord.OpGet:
+			r, err := next(ctx, call)
+			if err != nil {
+				return r, err
+			}
+			r.Value, err = c.decode(r.Value)
+			return r, err
+		default:
+			return next(ctx, call)
+		}
+	}, nil
+}
+
+// codec encodes and decodes values.
+type codec struct {
+	cfg Config
+
+	// zstdOnce lazily creates the Zstandard encoder and decoder
+	zstdOnce sync.Once
+	zstdEnc  *zstd.Encoder
+	zstdDec  *zstd.Decoder
+	zstdErr  error
+}
+
+// zstd returns the shared Zstandard encoder and decoder, which are safe for concurrent use.
+func (c *codec) zstd() (*zstd.Encoder, *zstd.Decoder, error) {
+	c.zstdOnce.Do(func() {
+		c.zstdEnc, c.zstdErr = zstd.NewWriter(nil)
+		if c.zstdErr != nil {
+			return
+		}
+		c.zstdDec, c.zstdErr = zstd.NewReader(nil, zstd.WithDecoderConcurrency(1))
+	})
+	return c.zstdEnc, c.zstdDec, c.zstdErr
+}
+
+// encode compresses data, prefixing the result with a header byte.
+func (c *codec) encode(data []byte) ([]byte, error) {
+	if len(data) < c.cfg.Threshold {
+		return append([]byte{byte(raw)}, data...), nil
+	}
+
+	out := []byte{byte(c.cfg.Algorithm)}
+	switch c.cfg.Algorithm {
+	case Gzip:
+		buf := bytes.NewBuffer(out)
+		w := gzip.NewWriter(buf)
+		if _, err := w.Write(data); err != nil {
+			return nil, fmt.Errorf("unable to compress value - %w", err)
+		}
+		if err := w.Close(); err != nil {
+			return nil, fmt.Errorf("unable to compress value - %w", err)
+		}
+		out = buf.Bytes()
+	case Zstd:
+		enc, _, err := c.zstd()
+		if err != nil {
+			return nil, fmt.Errorf("unable to compress value - %w", err)
+		}
+		out = enc.EncodeAll(data, out)
+	case Snappy:
+		out = append(out, snappy.Encode(nil, data)...)
+	}
+
+	// Store values that did not shrink raw
+	if len(out) >= len(data)+1 {
+		return append([]byte{byte(raw)}, data...), nil
+	}
+	return out, nil
+}
+
+// decode decompresses data based on its header byte. Data without a recognized header is returned as-is.
+func (c *codec) decode(data []byte) ([]byte, error) {
+	if len(data) == 0 {
+		return data, nil
+	}
+
+	switch Algorithm(data[0]) {
+	case raw:
+		return data[1:], nil
+	case Gzip:
+		r, err := gzip.NewReader(bytes.NewReader(data[1:]))
+		if err != nil {
+			return nil, fmt.Errorf("unable to decompress value - %w", err)
+		}
+		defer r.Close()
+		out, err := io.ReadAll(r)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decompress value - %w", err)
+		}
+		return out, nil
+	case Zstd:
+		_, dec, err := c.zstd()
+		if err != nil {
+			return nil, fmt.Errorf("unable to decompress value - %w", err)
+		}
+		out, err := dec.DecodeAll(data[1:], nil)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decompress value - %w", err)
+		}
+		return out, nil
+	case Snappy:
+		out, err := snappy.Decode(nil, data[1:])
+		if err != nil {
+			return nil, fmt.Errorf("unable to decompress value - %w", err)
+		}
+		return out, nil
+	default:
+		return data, nil
+	}
+}
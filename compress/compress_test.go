@@ -0,0 +1,151 @@
+package compress
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/madflojo/hord"
+	"github.com/madflojo/hord/drivers/hashmap"
+)
+
+func TestCompress(t *testing.T) {
+	large := []byte(strings.Repeat(`{"name":"hord","type":"database"}`, 100))
+	small := []byte(`{"name":"hord"}`)
+
+	for name, algo := range map[string]Algorithm{"Default": 0, "Gzip": Gzip, "Zstd": Zstd, "Snappy": Snappy} {
+		t.Run(name, func(t *testing.T) {
+			store, err := hashmap.Dial(hashmap.Config{})
+			if err != nil {
+				t.Fatalf("Unexpected error creating hashmap - %s", err)
+			}
+			defer store.Close()
+
+			db, err := Wrap(store, Config{Algorithm: algo, Threshold: 64})
+			if err != nil {
+				t.Fatalf("Unexpected error wrapping database - %s", err)
+			}
+
+			if err := db.Set("large", large); err != nil {
+				t.Fatalf("Unexpected error - %s", err)
+			}
+			if err := db.(hord.TTLDatabase).SetWithTTL("small", small, time.Minute); err != nil {
+				t.Fatalf("Unexpected error - %s", err)
+			}
+
+			stored, err := store.Get("large")
+			if err != nil {
+				t.Fatalf("Unexpected error - %s", err)
+			}
+			expected := algo
+			if expected == 0 {
+				expected = Gzip
+			}
+			if Algorithm(stored[0]) != expected || len(stored) >= len(large) {
+				t.Errorf("Expected value to be compressed with header %d, got header %d and %d bytes", expected, stored[0], len(stored))
+			}
+
+			stored, err = store.Get("small")
+			if err != nil {
+				t.Fatalf("Unexpected error - %s", err)
+			}
+			if Algorithm(stored[0]) != raw || !bytes.Equal(stored[1:], small) {
+				t.Errorf("Expected value below threshold to be stored raw, got %q", stored)
+			}
+
+			for key, value := range map[string][]byte{"large": large, "small": small} {
+				data, err := db.Get(key)
+				if err != nil {
+					t.Fatalf("Unexpected error - %s", err)
+				}
+				if !bytes.Equal(data, value) {
+					t.Errorf("Unexpected value for %s", key)
+				}
+			}
+		})
+	}
+}
+
+func TestMixedValues(t *testing.T) {
+	store, err := hashmap.Dial(hashmap.Config{})
+	if err != nil {
+		t.Fatalf("Unexpected error creating hashmap - %s", err)
+	}
+	defer store.Close()
+
+	large := []byte(strings.Repeat("hord ", 200))
+
+	// Write values with each algorithm, then read them back with a different configuration
+	for _, algo := range []Algorithm{Gzip, Zstd, Snappy} {
+		db, err := Wrap(store, Config{Algorithm: algo, Threshold: 1})
+		if err != nil {
+			t.Fatalf("Unexpected error wrapping database - %s", err)
+		}
+		if err := db.Set(string(rune('a'+algo)), large); err != nil {
+			t.Fatalf("Unexpected error - %s", err)
+		}
+	}
+
+	// Values written before compression was enabled
+	if err := store.Set("legacy", []byte(`{"legacy":true}`)); err != nil {
+		t.Fatalf("Unexpected error - %s", err)
+	}
+
+	db, err := Wrap(store, Config{})
+	if err != nil {
+		t.Fatalf("Unexpected error wrapping database - %s", err)
+	}
+
+	for _, algo := range []Algorithm{Gzip, Zstd, Snappy} {
+		data, err := db.Get(string(rune('a' + algo)))
+		if err != nil || !bytes.Equal(data, large) {
+			t.Errorf("Unexpected value for algorithm %d - %v", algo, err)
+		}
+	}
+
+	data, err := db.Get("legacy")
+	if err != nil || string(data) != `{"legacy":true}` {
+		t.Errorf("Expected legacy value to be returned as-is, got %q - %v", data, err)
+	}
+
+	t.Run("Corrupt", func(t *testing.T) {
+		if err := store.Set("corrupt", []byte{byte(Gzip), 'x', 'y'}); err != nil {
+			t.Fatalf("Unexpected error - %s", err)
+		}
+		if _, err := db.Get("corrupt"); err == nil {
+			t.Errorf("Expected error decompressing corrupt value")
+		}
+	})
+
+	t.Run("Miss", func(t *testing.T) {
+		if _, err := db.Get("missing"); err != hord.ErrNil {
+			t.Errorf("Expected ErrNil, got %v", err)
+		}
+	})
+
+	t.Run("Invalid Data", func(t *testing.T) {
+		if err := db.Set("empty", nil); err != hord.ErrInvalidData {
+			t.Errorf("Expected ErrInvalidData, got %v", err)
+		}
+	})
+}
+
+func TestIncompressible(t *testing.T) {
+	c := &codec{cfg: Config{Algorithm: Gzip, Threshold: 1}}
+	data := []byte("ab")
+
+	out, err := c.encode(data)
+	if err != nil {
+		t.Fatalf("Unexpected error - %s", err)
+	}
+	if Algorithm(out[0]) != raw || !bytes.Equal(out[1:], data) {
+		t.Errorf("Expected value that does not shrink to be stored raw, got %v", out)
+	}
+}
+
+func TestInvalidAlgorithm(t *testing.T) {
+	if _, err := Wrap(nil, Config{Algorithm: Snappy + 1}); err != ErrInvalidAlgorithm {
+		t.Errorf("Expected ErrInvalidAlgorithm, got %v", err)
+	}
+}
@@ -0,0 +1,483 @@
+/*
+Package config provides a declarative way to describe and dial a Hord database.
+
+Rather than importing and configuring a specific driver in Go code, applications can describe the database within a
+YAML or JSON document or HORD_* environment variables. To use this package, import it as follows:
+
+	import (
+	    "github.com/madflojo/hord/config"
+	)
+
+# Configuration Files
+
+The driver field selects the database driver, and the section with the same name provides its configuration.
+
+	driver: redis
+	redis:
+	  server: localhost:6379
+	  database: 2
+	  max_active: 50
+	  read_timeout: 2s
+
+Caches are composed by selecting the cache driver and describing both the database and cache.
+
+	driver: cache
+	cache:
+	  type: lookaside
+	  database:
+	    driver: cassandra
+	    cassandra:
+	      hosts: [node1, node2]
+	      keyspace: hord
+	  cache:
+	    driver: redis
+	    redis:
+	      server: localhost:6379
+
+Caching strategy options, such as coalesce for lookaside, queue_size, flush_interval, and batch_size for writebehind,
+or ttl and refresh_factor for refreshahead, are set alongside the cache type.
+
+	driver: cache
+	cache:
+	  type: refreshahead
+	  ttl: 5m
+	  refresh_factor: 0.75
+	  database:
+	    driver: cassandra
+	    cassandra:
+	      hosts: [node1, node2]
+	  cache:
+	    driver: hashmap
+
+A DSN supported by hord.Open can be used in place of a driver section.
+
+	driver: redis
+	dsn: redis://localhost:6379/2
+
+Load the configuration and dial the database.
+
+	cfg, err := config.Load("hord.yaml")
+	if err != nil {
+	    // Handle configuration error
+	}
+
+	db, err := cfg.Dial()
+	if err != nil {
+	    // Handle connection error
+	}
+
+# Environment Variables
+
+FromEnv builds the same configuration from environment variables. Each variable is named using the HORD_ prefix
+followed by the upper-case path of the field, with nested sections separated by underscores. Lists are comma
+separated.
+
+	HORD_DRIVER=cache
+	HORD_CACHE_TYPE=lookaside
+	HORD_CACHE_DATABASE_DRIVER=cassandra
+	HORD_CACHE_DATABASE_CASSANDRA_HOSTS=node1,node2
+	HORD_CACHE_CACHE_DRIVER=redis
+	HORD_CACHE_CACHE_REDIS_SERVER=localhost:6379
+*/
+package config
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/madflojo/hord"
+	"github.com/madflojo/hord/cache"
+	"github.com/madflojo/hord/drivers/bbolt"
+	"github.com/madflojo/hord/drivers/cassandra"
+	"github.com/madflojo/hord/drivers/hashmap"
+	"github.com/madflojo/hord/drivers/nats"
+	"github.com/madflojo/hord/drivers/redis"
+	"gopkg.in/yaml.v3"
+)
+
+// Driver names used to select a database driver.
+const (
+	Bbolt     = "bbolt"
+	Cache     = "cache"
+	Cassandra = "cassandra"
+	Hashmap   = "hashmap"
+	NATS      = "nats"
+	Redis     = "redis"
+)
+
+// Config describes a Hord database. Driver selects the database driver, and the section with the same name provides
+// its configuration. Alternatively, DSN may be set to a URL supported by hord.Open.
+type Config struct {
+	// Driver selects the database driver.
+	Driver string `json:"driver" yaml:"driver"`
+
+	// DSN is a data source name URL passed to hord.Open. When set, driver sections are ignored.
+	DSN string `json:"dsn,omitempty" yaml:"dsn,omitempty"`
+
+	// Bbolt configures the bbolt driver.
+	Bbolt *BboltConfig `json:"bbolt,omitempty" yaml:"bbolt,omitempty"`
+
+	// Cache composes a cache from two database configurations.
+	Cache *CacheConfig `json:"cache,omitempty" yaml:"cache,omitempty"`
+
+	// Cassandra configures the Cassandra driver.
+	Cassandra *CassandraConfig `json:"cassandra,omitempty" yaml:"cassandra,omitempty"`
+
+	// Hashmap configures the hashmap driver.
+	Hashmap *HashmapConfig `json:"hashmap,omitempty" yaml:"hashmap,omitempty"`
+
+	// NATS configures the NATS driver.
+	NATS *NATSConfig `json:"nats,omitempty" yaml:"nats,omitempty"`
+
+	// Redis configures the Redis driver.
+	Redis *RedisConfig `json:"redis,omitempty" yaml:"redis,omitempty"`
+}
+
+// CacheConfig describes a cache composed of a database and a cache database.
+type CacheConfig struct {
+	// Type is the caching strategy, such as lookaside or writethrough.
+	Type cache.Type `json:"type" yaml:"type"`
+
+	// Database describes the primary database.
+	Database *Config `json:"database" yaml:"database"`
+
+	// Cache describes the database used as a cache.
+	Cache *Config `json:"cache" yaml:"cache"`
+
+	// Coalesce and FillTimeout configure lookaside caches.
+	Coalesce    bool     `json:"coalesce,omitempty" yaml:"coalesce,omitempty"`
+	FillTimeout Duration `json:"fill_timeout,omitempty" yaml:"fill_timeout,omitempty"`
+
+	// QueueSize, FlushInterval, and BatchSize configure writebehind caches.
+	QueueSize     int      `json:"queue_size,omitempty" yaml:"queue_size,omitempty"`
+	FlushInterval Duration `json:"flush_interval,omitempty" yaml:"flush_interval,omitempty"`
+	BatchSize     int      `json:"batch_size,omitempty" yaml:"batch_size,omitempty"`
+
+	// TTL and RefreshFactor configure refreshahead caches.
+	TTL           Duration `json:"ttl,omitempty" yaml:"ttl,omitempty"`
+	RefreshFactor float64  `json:"refresh_factor,omitempty" yaml:"refresh_factor,omitempty"`
+}
+
+// BboltConfig describes a bbolt.Config.
+type BboltConfig struct {
+	Bucket        string   `json:"bucket" yaml:"bucket"`
+	Filename      string   `json:"filename" yaml:"filename"`
+	Permissions   string   `json:"permissions,omitempty" yaml:"permissions,omitempty"`
+	Timeout       Duration `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+	SweepInterval Duration `json:"sweep_interval,omitempty" yaml:"sweep_interval,omitempty"`
+}
+
+// CassandraConfig describes a cassandra.Config.
+type CassandraConfig struct {
+	Hosts                      []string `json:"hosts" yaml:"hosts"`
+	User                       string   `json:"user,omitempty" yaml:"user,omitempty"`
+	Password                   string   `json:"password,omitempty" yaml:"password,omitempty"`
+	Port                       int      `json:"port,omitempty" yaml:"port,omitempty"`
+	Keyspace                   string   `json:"keyspace" yaml:"keyspace"`
+	Consistency                string   `json:"consistency,omitempty" yaml:"consistency,omitempty"`
+	EnableHostnameVerification bool     `json:"enable_hostname_verification,omitempty" yaml:"enable_hostname_verification,omitempty"`
+	ReplicationStrategy        string   `json:"replication_strategy,omitempty" yaml:"replication_strategy,omitempty"`
+	Replicas                   int      `json:"replicas,omitempty" yaml:"replicas,omitempty"`
+}
+
+// HashmapConfig describes a hashmap.Config.
+type HashmapConfig struct {
+	Filename      string   `json:"filename,omitempty" yaml:"filename,omitempty"`
+	SweepInterval Duration `json:"sweep_interval,omitempty" yaml:"sweep_interval,omitempty"`
+}
+
+// NATSConfig describes a nats.Config. Setting TLS enables TLS using the system defaults.
+type NATSConfig struct {
+	URL           string   `json:"url" yaml:"url"`
+	Bucket        string   `json:"bucket" yaml:"bucket"`
+	TTL           Duration `json:"ttl,omitempty" yaml:"ttl,omitempty"`
+	Servers       []string `json:"servers,omitempty" yaml:"servers,omitempty"`
+	TLS           bool     `json:"tls,omitempty" yaml:"tls,omitempty"`
+	SkipTLSVerify bool     `json:"skip_tls_verify,omitempty" yaml:"skip_tls_verify,omitempty"`
+}
+
+// RedisConfig describes a redis.Config. Setting TLS enables TLS using the system defaults.
+type RedisConfig struct {
+	Server          string   `json:"server" yaml:"server"`
+	Database        int      `json:"database,omitempty" yaml:"database,omitempty"`
+	Username        string   `json:"username,omitempty" yaml:"username,omitempty"`
+	Password        string   `json:"password,omitempty" yaml:"password,omitempty"`
+	ConnectTimeout  Duration `json:"connect_timeout,omitempty" yaml:"connect_timeout,omitempty"`
+	IdleTimeout     Duration `json:"idle_timeout,omitempty" yaml:"idle_timeout,omitempty"`
+	KeepAlive       Duration `json:"keep_alive,omitempty" yaml:"keep_alive,omitempty"`
+	MaxActive       int      `json:"max_active,omitempty" yaml:"max_active,omitempty"`
+	MaxConnLifetime Duration `json:"max_conn_lifetime,omitempty" yaml:"max_conn_lifetime,omitempty"`
+	MaxIdle         int      `json:"max_idle,omitempty" yaml:"max_idle,omitempty"`
+	ReadTimeout     Duration `json:"read_timeout,omitempty" yaml:"read_timeout,omitempty"`
+	WriteTimeout    Duration `json:"write_timeout,omitempty" yaml:"write_timeout,omitempty"`
+	TLS             bool     `json:"tls,omitempty" yaml:"tls,omitempty"`
+	SkipTLSVerify   bool     `json:"skip_tls_verify,omitempty" yaml:"skip_tls_verify,omitempty"`
+	SentinelMaster  string   `json:"sentinel_master,omitempty" yaml:"sentinel_master,omitempty"`
+	SentinelServers []string `json:"sentinel_servers,omitempty" yaml:"sentinel_servers,omitempty"`
+}
+
+// Duration is a time.Duration that is decoded from a duration string such as "1m30s".
+type Duration time.Duration
+
+// UnmarshalText parses a duration string.
+func (d *Duration) UnmarshalText(b []byte) error {
+	v, err := time.ParseDuration(string(b))
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+// MarshalText returns the duration as a string.
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(time.Duration(d).String()), nil
+}
+
+// Parse decodes a YAML or JSON document into a Config. As YAML is a superset of JSON, both formats are accepted.
+func Parse(data []byte) (Config, error) {
+	var cfg Config
+	err := yaml.Unmarshal(data, &cfg)
+	if err != nil {
+		return cfg, fmt.Errorf("unable to parse configuration - %w", err)
+	}
+	return cfg, cfg.Validate()
+}
+
+// ParseJSON decodes a JSON document into a Config.
+func ParseJSON(data []byte) (Config, error) {
+	var cfg Config
+	err := json.Unmarshal(data, &cfg)
+	if err != nil {
+		return cfg, fmt.Errorf("unable to parse configuration - %w", err)
+	}
+	return cfg, cfg.Validate()
+}
+
+// Load reads and decodes a YAML or JSON configuration file.
+func Load(filename string) (Config, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return Config{}, fmt.Errorf("unable to read configuration file - %w", err)
+	}
+	return Parse(data)
+}
+
+// Validate checks that a driver is selected and its configuration section is provided.
+func (c Config) Validate() error {
+	if c.DSN != "" {
+		return nil
+	}
+
+	var ok bool
+	switch c.Driver {
+	case Bbolt:
+		ok = c.Bbolt != nil
+	case Cache:
+		if c.Cache == nil {
+			break
+		}
+		if c.Cache.Database == nil || c.Cache.Cache == nil {
+			return fmt.Errorf("cache configuration must include database and cache")
+		}
+		if err := c.Cache.Database.Validate(); err != nil {
+			return fmt.Errorf("invalid cache database configuration - %w", err)
+		}
+		if err := c.Cache.Cache.Validate(); err != nil {
+			return fmt.Errorf("invalid cache configuration - %w", err)
+		}
+		ok = true
+	case Cassandra:
+		ok = c.Cassandra != nil
+	case Hashmap:
+		// Hashmap can be used without any configuration
+		ok = true
+	case NATS:
+		ok = c.NATS != nil
+	case Redis:
+		ok = c.Redis != nil
+	case "":
+		return fmt.Errorf("driver must be specified")
+	default:
+		return fmt.Errorf("unknown driver %q", c.Driver)
+	}
+
+	if !ok {
+		return fmt.Errorf("missing %s configuration", c.Driver)
+	}
+	return nil
+}
+
+// Dial connects to the database described by the Config. As with each driver's Dial, Setup should be called before
+// using the returned Database.
+func (c Config) Dial() (hord.Database, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	if c.DSN != "" {
+		return hord.Open(c.DSN)
+	}
+
+	var db hord.Database
+	var err error
+	switch c.Driver {
+	case Bbolt:
+		var cfg bbolt.Config
+		cfg, err = c.Bbolt.Config()
+		if err != nil {
+			return nil, err
+		}
+		db, err = bbolt.Dial(cfg)
+	case Cache:
+		return c.Cache.Dial()
+	case Cassandra:
+		db, err = cassandra.Dial(c.Cassandra.Config())
+	case Hashmap:
+		var cfg hashmap.Config
+		if c.Hashmap != nil {
+			cfg = c.Hashmap.Config()
+		}
+		db, err = hashmap.Dial(cfg)
+	case NATS:
+		db, err = nats.Dial(c.NATS.Config())
+	case Redis:
+		db, err = redis.Dial(c.Redis.Config())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return db, nil
+}
+
+// Dial connects to both the database and cache, composing them using the configured cache Type.
+func (c CacheConfig) Dial() (hord.Database, error) {
+	if c.Database == nil || c.Cache == nil {
+		return nil, fmt.Errorf("cache configuration must include database and cache")
+	}
+
+	database, err := c.Database.Dial()
+	if err != nil {
+		return nil, fmt.Errorf("unable to dial cache database - %w", err)
+	}
+
+	cacheDB, err := c.Cache.Dial()
+	if err != nil {
+		database.Close()
+		return nil, fmt.Errorf("unable to dial cache - %w", err)
+	}
+
+	cfg := c.Config()
+	cfg.Database = database
+	cfg.Cache = cacheDB
+	db, err := cache.Dial(cfg)
+	if err != nil {
+		database.Close()
+		cacheDB.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+// Config returns the cache.Config described by the CacheConfig. The Database and Cache are set when dialing.
+func (c CacheConfig) Config() cache.Config {
+	return cache.Config{
+		Type:          c.Type,
+		Coalesce:      c.Coalesce,
+		FillTimeout:   time.Duration(c.FillTimeout),
+		QueueSize:     c.QueueSize,
+		FlushInterval: time.Duration(c.FlushInterval),
+		BatchSize:     c.BatchSize,
+		TTL:           time.Duration(c.TTL),
+		RefreshFactor: c.RefreshFactor,
+	}
+}
+
+// Config returns the bbolt.Config described by the BboltConfig. Permissions are parsed as an octal string.
+func (c BboltConfig) Config() (bbolt.Config, error) {
+	cfg := bbolt.Config{
+		Bucketname:    c.Bucket,
+		Filename:      c.Filename,
+		Timeout:       time.Duration(c.Timeout),
+		SweepInterval: time.Duration(c.SweepInterval),
+	}
+
+	if c.Permissions != "" {
+		perm, err := strconv.ParseUint(c.Permissions, 8, 32)
+		if err != nil {
+			return cfg, fmt.Errorf("invalid bbolt permissions %q - %w", c.Permissions, err)
+		}
+		cfg.Permissions = os.FileMode(perm)
+	}
+
+	return cfg, nil
+}
+
+// Config returns the cassandra.Config described by the CassandraConfig.
+func (c CassandraConfig) Config() cassandra.Config {
+	return cassandra.Config{
+		Hosts:                      c.Hosts,
+		User:                       c.User,
+		Password:                   c.Password,
+		Port:                       c.Port,
+		Keyspace:                   c.Keyspace,
+		Consistency:                c.Consistency,
+		EnableHostnameVerification: c.EnableHostnameVerification,
+		ReplicationStrategy:        c.ReplicationStrategy,
+		Replicas:                   c.Replicas,
+	}
+}
+
+// Config returns the hashmap.Config described by the HashmapConfig.
+func (c HashmapConfig) Config() hashmap.Config {
+	return hashmap.Config{
+		Filename:      c.Filename,
+		SweepInterval: time.Duration(c.SweepInterval),
+	}
+}
+
+// Config returns the nats.Config described by the NATSConfig.
+func (c NATSConfig) Config() nats.Config {
+	cfg := nats.Config{
+		URL:           c.URL,
+		Bucket:        c.Bucket,
+		TTL:           time.Duration(c.TTL),
+		Servers:       c.Servers,
+		SkipTLSVerify: c.SkipTLSVerify,
+	}
+	if c.TLS {
+		cfg.TLSConfig = &tls.Config{}
+	}
+	return cfg
+}
+
+// Config returns the redis.Config described by the RedisConfig.
+func (c RedisConfig) Config() redis.Config {
+	cfg := redis.Config{
+		ConnectTimeout:  time.Duration(c.ConnectTimeout),
+		Database:        c.Database,
+		IdleTimeout:     time.Duration(c.IdleTimeout),
+		KeepAlive:       time.Duration(c.KeepAlive),
+		MaxActive:       c.MaxActive,
+		MaxConnLifetime: time.Duration(c.MaxConnLifetime),
+		MaxIdle:         c.MaxIdle,
+		Username:        c.Username,
+		Password:        c.Password,
+		ReadTimeout:     time.Duration(c.ReadTimeout),
+		SentinelConfig: redis.SentinelConfig{
+			Servers: c.SentinelServers,
+			Master:  c.SentinelMaster,
+		},
+		Server:        c.Server,
+		SkipTLSVerify: c.SkipTLSVerify,
+		WriteTimeout:  time.Duration(c.WriteTimeout),
+	}
+	if c.TLS {
+		cfg.TLSConfig = &tls.Config{}
+	}
+	return cfg
+}
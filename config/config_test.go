@@ -0,0 +1,353 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/madflojo/hord/cache"
+	"github.com/madflojo/hord/cache/lookaside"
+	"github.com/madflojo/hord/cache/refreshahead"
+	"github.com/madflojo/hord/drivers/hashmap"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("YAML", func(t *testing.T) {
+		cfg, err := Parse([]byte(`
+driver: redis
+redis:
+  server: localhost:6379
+  database: 2
+  max_active: 50
+  read_timeout: 2s
+  tls: true
+  sentinel_servers: [s1, s2]
+`))
+		if err != nil {
+			t.Fatalf("Unexpected error parsing configuration - %s", err)
+		}
+
+		r := cfg.Redis.Config()
+		if r.Server != "localhost:6379" || r.Database != 2 || r.MaxActive != 50 || r.ReadTimeout != 2*time.Second {
+			t.Errorf("Unexpected redis config - %+v", r)
+		}
+		if r.TLSConfig == nil || len(r.SentinelConfig.Servers) != 2 {
+			t.Errorf("Unexpected redis config - %+v", r)
+		}
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		doc := []byte(`{"driver": "nats", "nats": {"url": "nats://localhost:4222", "bucket": "config", "ttl": "1h"}}`)
+		for name, parse := range map[string]func([]byte) (Config, error){"Parse": Parse, "ParseJSON": ParseJSON} {
+			cfg, err := parse(doc)
+			if err != nil {
+				t.Fatalf("Unexpected error from %s - %s", name, err)
+			}
+
+			n := cfg.NATS.Config()
+			if n.URL != "nats://localhost:4222" || n.Bucket != "config" || n.TTL != time.Hour {
+				t.Errorf("Unexpected nats config from %s - %+v", name, n)
+			}
+		}
+	})
+
+	t.Run("Cache", func(t *testing.T) {
+		cfg, err := Parse([]byte(`
+driver: cache
+cache:
+  type: lookaside
+  database:
+    driver: cassandra
+    cassandra:
+      hosts: [node1, node2]
+      keyspace: hord
+  cache:
+    driver: bbolt
+    bbolt:
+      bucket: cache
+      filename: /tmp/cache.db
+      permissions: "0600"
+`))
+		if err != nil {
+			t.Fatalf("Unexpected error parsing configuration - %s", err)
+		}
+
+		if cfg.Cache.Type != cache.Lookaside {
+			t.Errorf("Unexpected cache type - %s", cfg.Cache.Type)
+		}
+		if c := cfg.Cache.Database.Cassandra.Config(); len(c.Hosts) != 2 || c.Keyspace != "hord" {
+			t.Errorf("Unexpected cassandra config - %+v", c)
+		}
+		b, err := cfg.Cache.Cache.Bbolt.Config()
+		if err != nil || b.Permissions != 0600 || b.Bucketname != "cache" {
+			t.Errorf("Unexpected bbolt config - %+v, %v", b, err)
+		}
+	})
+
+	t.Run("Cache Options", func(t *testing.T) {
+		cfg, err := Parse([]byte(`
+driver: cache
+cache:
+  type: writebehind
+  coalesce: true
+  fill_timeout: 5s
+  queue_size: 500
+  flush_interval: 250ms
+  batch_size: 50
+  ttl: 5m
+  refresh_factor: 0.75
+  database: {driver: hashmap}
+  cache: {driver: hashmap}
+`))
+		if err != nil {
+			t.Fatalf("Unexpected error parsing configuration - %s", err)
+		}
+
+		c := cfg.Cache.Config()
+		if c.Type != cache.WriteBehind || !c.Coalesce || c.FillTimeout != 5*time.Second {
+			t.Errorf("Unexpected cache config - %+v", c)
+		}
+		if c.QueueSize != 500 || c.FlushInterval != 250*time.Millisecond || c.BatchSize != 50 {
+			t.Errorf("Unexpected cache config - %+v", c)
+		}
+		if c.TTL != 5*time.Minute || c.RefreshFactor != 0.75 {
+			t.Errorf("Unexpected cache config - %+v", c)
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		docs := []string{
+			`driver: [`,
+			`redis: {server: localhost}`,
+			`driver: unknown`,
+			`driver: redis`,
+			`{driver: redis, redis: {read_timeout: soon}}`,
+			`{driver: cache, cache: {type: lookaside, database: {driver: hashmap}}}`,
+			`{driver: cache, cache: {type: lookaside, database: {driver: redis}, cache: {driver: hashmap}}}`,
+		}
+		for _, doc := range docs {
+			if _, err := Parse([]byte(doc)); err == nil {
+				t.Errorf("Expected error parsing %s", doc)
+			}
+		}
+
+		if _, err := ParseJSON([]byte(`{"driver": `)); err == nil {
+			t.Errorf("Expected error parsing invalid JSON")
+		}
+
+		if _, err := (BboltConfig{Permissions: "rw"}).Config(); err == nil {
+			t.Errorf("Expected error parsing invalid permissions")
+		}
+	})
+}
+
+func TestLoad(t *testing.T) {
+	f, err := os.CreateTemp("", "hord-*.yaml")
+	if err != nil {
+		t.Fatalf("Unable to create temp file - %s", err)
+	}
+	defer os.Remove(f.Name())
+
+	_, err = f.WriteString("driver: hashmap\nhashmap:\n  sweep_interval: 30s\n")
+	f.Close()
+	if err != nil {
+		t.Fatalf("Unable to write temp file - %s", err)
+	}
+
+	cfg, err := Load(f.Name())
+	if err != nil {
+		t.Fatalf("Unexpected error loading configuration - %s", err)
+	}
+	if cfg.Hashmap.Config().SweepInterval != 30*time.Second {
+		t.Errorf("Unexpected hashmap config - %+v", cfg.Hashmap)
+	}
+
+	_, err = Load(f.Name() + ".missing")
+	if err == nil {
+		t.Errorf("Expected error loading missing file")
+	}
+}
+
+func TestFromEnv(t *testing.T) {
+	t.Run("Driver", func(t *testing.T) {
+		cfg, err := fromEnv(EnvPrefix, []string{
+			"HORD_DRIVER=redis",
+			"HORD_REDIS_SERVER=localhost:6379",
+			"HORD_REDIS_MAX_ACTIVE=50",
+			"HORD_REDIS_READ_TIMEOUT=2s",
+			"HORD_REDIS_SKIP_TLS_VERIFY=true",
+			"HORD_REDIS_SENTINEL_SERVERS=s1,s2",
+			"PATH=/usr/bin",
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error reading environment - %s", err)
+		}
+
+		r := cfg.Redis.Config()
+		if r.Server != "localhost:6379" || r.MaxActive != 50 || r.ReadTimeout != 2*time.Second || !r.SkipTLSVerify {
+			t.Errorf("Unexpected redis config - %+v", r)
+		}
+		if len(r.SentinelConfig.Servers) != 2 {
+			t.Errorf("Unexpected sentinel servers - %+v", r.SentinelConfig.Servers)
+		}
+		if cfg.NATS != nil || cfg.Cache != nil {
+			t.Errorf("Unexpected sections allocated - %+v", cfg)
+		}
+	})
+
+	t.Run("Cache", func(t *testing.T) {
+		cfg, err := fromEnv(EnvPrefix, []string{
+			"HORD_DRIVER=cache",
+			"HORD_CACHE_TYPE=lookaside",
+			"HORD_CACHE_DATABASE_DRIVER=cassandra",
+			"HORD_CACHE_DATABASE_CASSANDRA_HOSTS=node1,node2",
+			"HORD_CACHE_CACHE_DRIVER=hashmap",
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error reading environment - %s", err)
+		}
+
+		if cfg.Cache.Type != cache.Lookaside || cfg.Cache.Database.Driver != Cassandra || cfg.Cache.Cache.Driver != Hashmap {
+			t.Errorf("Unexpected cache config - %+v", cfg.Cache)
+		}
+		if len(cfg.Cache.Database.Cassandra.Hosts) != 2 {
+			t.Errorf("Unexpected cassandra hosts - %+v", cfg.Cache.Database.Cassandra.Hosts)
+		}
+	})
+
+	t.Run("Cache Options", func(t *testing.T) {
+		cfg, err := fromEnv(EnvPrefix, []string{
+			"HORD_DRIVER=cache",
+			"HORD_CACHE_TYPE=refreshahead",
+			"HORD_CACHE_TTL=5m",
+			"HORD_CACHE_REFRESH_FACTOR=0.75",
+			"HORD_CACHE_QUEUE_SIZE=500",
+			"HORD_CACHE_DATABASE_DRIVER=hashmap",
+			"HORD_CACHE_CACHE_DRIVER=hashmap",
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error reading environment - %s", err)
+		}
+
+		c := cfg.Cache.Config()
+		if c.Type != cache.RefreshAhead || c.TTL != 5*time.Minute || c.RefreshFactor != 0.75 || c.QueueSize != 500 {
+			t.Errorf("Unexpected cache config - %+v", c)
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		envs := [][]string{
+			{},
+			{"HORD_DRIVER=redis", "HORD_REDIS_MAX_ACTIVE=many"},
+			{"HORD_DRIVER=redis", "HORD_REDIS_TLS=maybe"},
+			{"HORD_DRIVER=redis", "HORD_REDIS_READ_TIMEOUT=soon"},
+			{
+				"HORD_DRIVER=cache", "HORD_CACHE_REFRESH_FACTOR=most",
+				"HORD_CACHE_DATABASE_DRIVER=hashmap", "HORD_CACHE_CACHE_DRIVER=hashmap",
+			},
+		}
+		for _, env := range envs {
+			if _, err := fromEnv(EnvPrefix, env); err == nil {
+				t.Errorf("Expected error reading %v", env)
+			}
+		}
+	})
+
+	t.Run("Process Environment", func(t *testing.T) {
+		t.Setenv("HORD_DRIVER", "hashmap")
+		cfg, err := FromEnv()
+		if err != nil || cfg.Driver != Hashmap {
+			t.Errorf("Unexpected config %+v - %v", cfg, err)
+		}
+	})
+}
+
+func TestDial(t *testing.T) {
+	t.Run("Hashmap", func(t *testing.T) {
+		db, err := Config{Driver: Hashmap}.Dial()
+		if err != nil {
+			t.Fatalf("Unexpected error dialing - %s", err)
+		}
+		defer db.Close()
+
+		if _, ok := db.(*hashmap.Database); !ok {
+			t.Errorf("Expected hashmap database, got %T", db)
+		}
+	})
+
+	t.Run("DSN", func(t *testing.T) {
+		db, err := Config{DSN: "hashmap://"}.Dial()
+		if err != nil {
+			t.Fatalf("Unexpected error dialing - %s", err)
+		}
+		defer db.Close()
+
+		if _, ok := db.(*hashmap.Database); !ok {
+			t.Errorf("Expected hashmap database, got %T", db)
+		}
+	})
+
+	t.Run("Cache", func(t *testing.T) {
+		cfg, err := Parse([]byte(`
+driver: cache
+cache:
+  type: lookaside
+  database: {driver: hashmap}
+  cache: {driver: hashmap}
+`))
+		if err != nil {
+			t.Fatalf("Unexpected error parsing configuration - %s", err)
+		}
+
+		db, err := cfg.Dial()
+		if err != nil {
+			t.Fatalf("Unexpected error dialing - %s", err)
+		}
+		defer db.Close()
+
+		if _, ok := db.(*lookaside.Lookaside); !ok {
+			t.Errorf("Expected lookaside cache, got %T", db)
+		}
+		if err := db.Set("key", []byte("value")); err != nil {
+			t.Errorf("Unexpected error using cache - %s", err)
+		}
+	})
+
+	t.Run("Cache Options", func(t *testing.T) {
+		cfg := Config{Driver: Cache, Cache: &CacheConfig{
+			Type:          cache.RefreshAhead,
+			RefreshFactor: 1,
+			Database:      &Config{Driver: Hashmap},
+			Cache:         &Config{Driver: Hashmap},
+		}}
+		_, err := cfg.Dial()
+		if !errors.Is(err, refreshahead.ErrInvalidRefreshFactor) {
+			t.Errorf("Expected ErrInvalidRefreshFactor, got %v", err)
+		}
+	})
+
+	t.Run("Invalid Cache Type", func(t *testing.T) {
+		cfg := Config{Driver: Cache, Cache: &CacheConfig{
+			Type:     cache.Type("unknown"),
+			Database: &Config{Driver: Hashmap},
+			Cache:    &Config{Driver: Hashmap},
+		}}
+		_, err := cfg.Dial()
+		if err != cache.ErrNoType {
+			t.Errorf("Expected ErrNoType, got %v", err)
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := Config{Driver: Redis}.Dial()
+		if err == nil {
+			t.Errorf("Expected error dialing without configuration")
+		}
+
+		_, err = Config{Driver: Bbolt, Bbolt: &BboltConfig{}}.Dial()
+		if err == nil {
+			t.Errorf("Expected error dialing bbolt without a bucket")
+		}
+	})
+}
@@ -0,0 +1,122 @@
+package config
+
+import (
+	"encoding"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// EnvPrefix is the prefix of environment variables read by FromEnv.
+const EnvPrefix = "HORD_"
+
+// FromEnv builds a Config from HORD_* environment variables. Variable names are derived from the YAML field names,
+// upper-cased and joined with underscores (e.g. HORD_REDIS_MAX_ACTIVE or HORD_CACHE_DATABASE_DRIVER).
+func FromEnv() (Config, error) {
+	return fromEnv(EnvPrefix, os.Environ())
+}
+
+// fromEnv builds a Config from the provided KEY=VALUE environment entries.
+func fromEnv(prefix string, environ []string) (Config, error) {
+	env := make(map[string]string)
+	for _, kv := range environ {
+		k, v, ok := strings.Cut(kv, "=")
+		if ok && strings.HasPrefix(k, prefix) {
+			env[k] = v
+		}
+	}
+
+	var cfg Config
+	if err := decodeEnv(reflect.ValueOf(&cfg).Elem(), prefix, env); err != nil {
+		return cfg, err
+	}
+	return cfg, cfg.Validate()
+}
+
+// textUnmarshaler is used to detect fields that decode themselves from text.
+var textUnmarshaler = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// decodeEnv sets the fields of the struct v from env using names derived from prefix and each field's YAML tag.
+// Nested struct pointers are only allocated when an environment variable exists for them.
+func decodeEnv(v reflect.Value, prefix string, env map[string]string) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		tag, _, _ := strings.Cut(t.Field(i).Tag.Get("yaml"), ",")
+		if tag == "" || tag == "-" {
+			continue
+		}
+		name := prefix + strings.ToUpper(tag)
+		f := v.Field(i)
+
+		// Nested sections
+		if f.Kind() == reflect.Pointer && f.Type().Elem().Kind() == reflect.Struct {
+			if !hasPrefix(env, name+"_") {
+				continue
+			}
+			f.Set(reflect.New(f.Type().Elem()))
+			if err := decodeEnv(f.Elem(), name+"_", env); err != nil {
+				return err
+			}
+			continue
+		}
+
+		s, ok := env[name]
+		if !ok {
+			continue
+		}
+		if err := setField(f, s); err != nil {
+			return fmt.Errorf("invalid value for %s - %w", name, err)
+		}
+	}
+	return nil
+}
+
+// setField parses s into the field f.
+func setField(f reflect.Value, s string) error {
+	if f.Addr().Type().Implements(textUnmarshaler) {
+		return f.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+	}
+
+	switch f.Kind() {
+	case reflect.String:
+		f.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		f.SetBool(b)
+	case reflect.Int:
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return err
+		}
+		f.SetInt(int64(n))
+	case reflect.Float64:
+		n, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return err
+		}
+		f.SetFloat(n)
+	case reflect.Slice:
+		if f.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported type %s", f.Type())
+		}
+		f.Set(reflect.ValueOf(strings.Split(s, ",")))
+	default:
+		return fmt.Errorf("unsupported type %s", f.Type())
+	}
+	return nil
+}
+
+// hasPrefix returns true if any key within env starts with prefix.
+func hasPrefix(env map[string]string, prefix string) bool {
+	for k := range env {
+		if strings.HasPrefix(k, prefix) {
+			return true
+		}
+	}
+	return false
+}
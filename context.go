@@ -0,0 +1,93 @@
+package hord
+
+import "context"
+
+// ContextDatabase is a context-aware variant of the Database interface. Drivers that implement ContextDatabase pass
+// the provided context's deadlines and cancellations through to the underlying database calls.
+//
+// Existing Database implementations can be converted into a ContextDatabase using the WrapContext function.
+type ContextDatabase interface {
+	Database
+
+	// SetupContext is a context-aware version of Setup.
+	SetupContext(ctx context.Context) error
+
+	// HealthCheckContext is a context-aware version of HealthCheck.
+	HealthCheckContext(ctx context.Context) error
+
+	// GetContext is a context-aware version of Get.
+	GetContext(ctx context.Context, key string) ([]byte, error)
+
+	// SetContext is a context-aware version of Set.
+	SetContext(ctx context.Context, key string, data []byte) error
+
+	// DeleteContext is a context-aware version of Delete.
+	DeleteContext(ctx context.Context, key string) error
+
+	// KeysContext is a context-aware version of Keys.
+	KeysContext(ctx context.Context) ([]string, error)
+}
+
+// WrapContext returns a ContextDatabase for the provided Database. If the Database already implements
+// ContextDatabase, it is returned as-is. Otherwise, the Database is wrapped with an adapter that checks the
+// context before calling the legacy context-free method. The adapter cannot interrupt a call that is already
+// in progress.
+func WrapContext(db Database) ContextDatabase {
+	if cdb, ok := db.(ContextDatabase); ok {
+		return cdb
+	}
+	return &contextAdapter{Database: db}
+}
+
+// contextAdapter adapts a legacy Database to the ContextDatabase interface.
+type contextAdapter struct {
+	Database
+}
+
+// SetupContext checks the context before calling Setup.
+func (a *contextAdapter) SetupContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return a.Setup()
+}
+
+// HealthCheckContext checks the context before calling HealthCheck.
+func (a *contextAdapter) HealthCheckContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return a.HealthCheck()
+}
+
+// GetContext checks the context before calling Get.
+func (a *contextAdapter) GetContext(ctx context.Context, key string) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return a.Get(key)
+}
+
+// SetContext checks the context before calling Set.
+func (a *contextAdapter) SetContext(ctx context.Context, key string, data []byte) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return a.Set(key, data)
+}
+
+// DeleteContext checks the context before calling Delete.
+func (a *contextAdapter) DeleteContext(ctx context.Context, key string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return a.Delete(key)
+}
+
+// KeysContext checks the context before calling Keys.
+func (a *contextAdapter) KeysContext(ctx context.Context) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return a.Keys()
+}
@@ -0,0 +1,86 @@
+package hord
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// fakeDatabase is a minimal Database implementation used to validate the context adapter.
+type fakeDatabase struct {
+	calls int
+}
+
+func (f *fakeDatabase) Setup() error                 { f.calls++; return nil }
+func (f *fakeDatabase) HealthCheck() error           { f.calls++; return nil }
+func (f *fakeDatabase) Get(_ string) ([]byte, error) { f.calls++; return []byte("value"), nil }
+func (f *fakeDatabase) Set(_ string, _ []byte) error { f.calls++; return nil }
+func (f *fakeDatabase) Delete(_ string) error        { f.calls++; return nil }
+func (f *fakeDatabase) Keys() ([]string, error)      { f.calls++; return []string{"key"}, nil }
+func (f *fakeDatabase) Close()                       {}
+
+func TestWrapContext(t *testing.T) {
+	t.Run("Active Context", func(t *testing.T) {
+		fake := &fakeDatabase{}
+		db := WrapContext(fake)
+		ctx := context.Background()
+
+		if err := db.SetupContext(ctx); err != nil {
+			t.Errorf("SetupContext returned error: %s", err)
+		}
+		if err := db.HealthCheckContext(ctx); err != nil {
+			t.Errorf("HealthCheckContext returned error: %s", err)
+		}
+		if v, err := db.GetContext(ctx, "key"); err != nil || string(v) != "value" {
+			t.Errorf("GetContext returned %s, %s", v, err)
+		}
+		if err := db.SetContext(ctx, "key", []byte("value")); err != nil {
+			t.Errorf("SetContext returned error: %s", err)
+		}
+		if err := db.DeleteContext(ctx, "key"); err != nil {
+			t.Errorf("DeleteContext returned error: %s", err)
+		}
+		if keys, err := db.KeysContext(ctx); err != nil || len(keys) != 1 {
+			t.Errorf("KeysContext returned %v, %s", keys, err)
+		}
+		if fake.calls != 6 {
+			t.Errorf("Expected 6 calls to the wrapped database, got %d", fake.calls)
+		}
+	})
+
+	t.Run("Canceled Context", func(t *testing.T) {
+		fake := &fakeDatabase{}
+		db := WrapContext(fake)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		if err := db.SetupContext(ctx); !errors.Is(err, context.Canceled) {
+			t.Errorf("SetupContext returned error: %s, expected %s", err, context.Canceled)
+		}
+		if err := db.HealthCheckContext(ctx); !errors.Is(err, context.Canceled) {
+			t.Errorf("HealthCheckContext returned error: %s, expected %s", err, context.Canceled)
+		}
+		if _, err := db.GetContext(ctx, "key"); !errors.Is(err, context.Canceled) {
+			t.Errorf("GetContext returned error: %s, expected %s", err, context.Canceled)
+		}
+		if err := db.SetContext(ctx, "key", []byte("value")); !errors.Is(err, context.Canceled) {
+			t.Errorf("SetContext returned error: %s, expected %s", err, context.Canceled)
+		}
+		if err := db.DeleteContext(ctx, "key"); !errors.Is(err, context.Canceled) {
+			t.Errorf("DeleteContext returned error: %s, expected %s", err, context.Canceled)
+		}
+		if _, err := db.KeysContext(ctx); !errors.Is(err, context.Canceled) {
+			t.Errorf("KeysContext returned error: %s, expected %s", err, context.Canceled)
+		}
+		if fake.calls != 0 {
+			t.Errorf("Expected no calls to the wrapped database, got %d", fake.calls)
+		}
+	})
+
+	t.Run("Already Context Aware", func(t *testing.T) {
+		db := WrapContext(&fakeDatabase{})
+		if WrapContext(db) != db {
+			t.Errorf("Expected WrapContext to return an existing ContextDatabase as-is")
+		}
+	})
+}
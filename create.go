@@ -0,0 +1,17 @@
+package hord
+
+// CreateDatabase is an optional interface implemented by drivers that can atomically insert a key only if it does not
+// already exist. Create is useful for idempotency tokens, job claims, and other operations that must happen exactly
+// once.
+//
+//	err := cdb.Create("job:1234", []byte("worker-a"))
+//	if errors.Is(err, hord.ErrKeyExists) {
+//	    // Another worker claimed the job
+//	}
+type CreateDatabase interface {
+	Database
+
+	// Create is used to insert data for the specified key only if the key does not exist. If the key already exists,
+	// ErrKeyExists is returned and the existing data is left unchanged.
+	Create(key string, data []byte) error
+}
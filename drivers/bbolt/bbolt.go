@@ -57,8 +57,13 @@ Hord provides a simple abstraction for working with BoltDB, with easy-to-use met
 package bbolt
 
 import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
 	"fmt"
 	"os"
+	"sync"
 	"time"
 
 	"github.com/madflojo/hord"
@@ -79,6 +84,10 @@ type Config struct {
 	// Timeout specifies the timeout duration for opening obtaining a file lock on the database file.
 	// Default value is 5 Seconds, a value of 0 is invalid.
 	Timeout time.Duration
+
+	// SweepInterval defines how often keys with an expired TTL are removed from the database. Expired keys are never
+	// returned regardless of this setting. Default is 1 minute.
+	SweepInterval time.Duration
 }
 
 // Database is an bbolt implementation of the hord.Database interface.
@@ -88,12 +97,24 @@ type Database struct {
 
 	// db is the underlying database.
 	db *bbolt.DB
+
+	// sweeper is used to start the expired key sweeper the first time a TTL is set
+	sweeper sync.Once
+
+	// stop is closed to stop the expired key sweeper
+	stop chan struct{}
+
+	// stopper ensures stop is only closed once
+	stopper sync.Once
+
+	// watchers notifies callers of Watch when keys change
+	watchers hord.Broadcaster
 }
 
 // Dial initializes and returns a new bbolt database instance.
 func Dial(cfg Config) (*Database, error) {
 	var err error
-	db := &Database{cfg: cfg}
+	db := &Database{cfg: cfg, stop: make(chan struct{})}
 
 	// Verify Bucket is set
 	if cfg.Bucketname == "" {
@@ -115,10 +136,15 @@ func Dial(cfg Config) (*Database, error) {
 		cfg.Timeout = time.Duration(5 * time.Second)
 	}
 
+	// Set Default Sweep Interval
+	if db.cfg.SweepInterval <= 0 {
+		db.cfg.SweepInterval = time.Minute
+	}
+
 	// Open database
 	db.db, err = bbolt.Open(cfg.Filename, cfg.Permissions, &bbolt.Options{Timeout: cfg.Timeout})
 	if err != nil {
-		return db, fmt.Errorf("unable to open database - %s", err)
+		return db, fmt.Errorf("unable to open database - %w", mapError(err))
 	}
 
 	return db, nil
@@ -127,21 +153,47 @@ func Dial(cfg Config) (*Database, error) {
 // Setup initializes the database by creating the necessary bucket if it doesn't exist.
 // Returns an error if the database is not connected or if there is an error creating the bucket.
 func (db *Database) Setup() error {
+	return db.SetupContext(context.Background())
+}
+
+// SetupContext is a context-aware version of Setup.
+func (db *Database) SetupContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	// Verify DB is connected
 	if db == nil || db.db == nil {
 		return hord.ErrNoDial
 	}
 
 	// Open Bucket
+	var pending bool
 	err := db.db.Update(func(tx *bbolt.Tx) error {
 		_, err := tx.CreateBucketIfNotExists([]byte(db.cfg.Bucketname))
 		if err != nil {
-			return fmt.Errorf("unable to open bucket - %s", err)
+			return fmt.Errorf("unable to open bucket - %w", err)
 		}
+
+		// Create expiry bucket used to track key TTLs
+		expiry, err := tx.CreateBucketIfNotExists(db.expiryBucket())
+		if err != nil {
+			return fmt.Errorf("unable to open expiry bucket - %w", err)
+		}
+
+		// Keys may have been set with a TTL before the database was re-opened
+		k, _ := expiry.Cursor().First()
+		pending = k != nil
 		return nil
 	})
 	if err != nil {
-		return err
+		return mapError(err)
+	}
+
+	if pending {
+		db.sweeper.Do(func() {
+			go db.sweep()
+		})
 	}
 
 	return nil
@@ -150,10 +202,19 @@ func (db *Database) Setup() error {
 // Get retrieves data from the bbolt database based on the provided key.
 // It returns the data associated with the key or an error if the key is invalid or the data does not exist.
 func (db *Database) Get(key string) ([]byte, error) {
+	return db.GetContext(context.Background(), key)
+}
+
+// GetContext is a context-aware version of Get.
+func (db *Database) GetContext(ctx context.Context, key string) ([]byte, error) {
 	if err := hord.ValidKey(key); err != nil {
 		return nil, err
 	}
 
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	// Verify DB is connected
 	if db == nil || db.db == nil {
 		return nil, hord.ErrNoDial
@@ -169,14 +230,14 @@ func (db *Database) Get(key string) ([]byte, error) {
 
 		// Fetch Data from Bucket
 		d := bucket.Get([]byte(key))
-		if d != nil {
+		if d != nil && !db.expired(tx, []byte(key), time.Now()) {
 			// Copy results into data as d will only be valid for the lifetime of this Tx
 			data = append(data, d...)
 		}
 		return nil
 	})
 	if err != nil {
-		return nil, fmt.Errorf("error while executing Get - %s", err)
+		return nil, fmt.Errorf("error while executing Get - %w", mapError(err))
 	}
 
 	// If no data returned, return ErrNil
@@ -189,6 +250,11 @@ func (db *Database) Get(key string) ([]byte, error) {
 // Set inserts or updates data in the bbolt database based on the provided key.
 // It returns an error if the key or data is invalid.
 func (db *Database) Set(key string, data []byte) error {
+	return db.SetContext(context.Background(), key, data)
+}
+
+// SetContext is a context-aware version of Set.
+func (db *Database) SetContext(ctx context.Context, key string, data []byte) error {
 	if err := hord.ValidKey(key); err != nil {
 		return err
 	}
@@ -197,6 +263,10 @@ func (db *Database) Set(key string, data []byte) error {
 		return err
 	}
 
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	// Verify DB is connected
 	if db == nil || db.db == nil {
 		return hord.ErrNoDial
@@ -212,24 +282,353 @@ func (db *Database) Set(key string, data []byte) error {
 		// Store Data into Bucket
 		err := bucket.Put([]byte(key), data)
 		if err != nil {
-			return fmt.Errorf("error while executing Set - %s", err)
+			return fmt.Errorf("error while executing Set - %w", err)
+		}
+
+		// Clear any previous expiration
+		if expiry := tx.Bucket(db.expiryBucket()); expiry != nil {
+			err = expiry.Delete([]byte(key))
+			if err != nil {
+				return fmt.Errorf("error while clearing expiry - %w", err)
+			}
 		}
 		return nil
 	})
 	if err != nil {
-		return fmt.Errorf("error while executing Set transaction - %s", err)
+		return fmt.Errorf("error while executing Set transaction - %w", mapError(err))
 	}
 
+	db.watchers.Publish(hord.Event{Type: hord.EventPut, Key: key, Value: data})
+	return nil
+}
+
+// Create inserts data into the bbolt database only if the provided key does not exist. The existence check and
+// insert are performed within a single transaction. It returns hord.ErrKeyExists if the key is already present.
+func (db *Database) Create(key string, data []byte) error {
+	if err := hord.ValidKey(key); err != nil {
+		return err
+	}
+
+	if err := hord.ValidData(data); err != nil {
+		return err
+	}
+
+	// Verify DB is connected
+	if db == nil || db.db == nil {
+		return hord.ErrNoDial
+	}
+
+	err := db.db.Update(func(tx *bbolt.Tx) error {
+		// Open Bucket for this Tx
+		bucket := tx.Bucket([]byte(db.cfg.Bucketname))
+		if bucket == nil {
+			return fmt.Errorf("bucket does not exist")
+		}
+
+		// Check if Key exists
+		if bucket.Get([]byte(key)) != nil && !db.expired(tx, []byte(key), time.Now()) {
+			return hord.ErrKeyExists
+		}
+
+		// Store Data into Bucket
+		err := bucket.Put([]byte(key), data)
+		if err != nil {
+			return fmt.Errorf("error while executing Create - %w", err)
+		}
+
+		// Clear any previous expiration
+		if expiry := tx.Bucket(db.expiryBucket()); expiry != nil {
+			err = expiry.Delete([]byte(key))
+			if err != nil {
+				return fmt.Errorf("error while clearing expiry - %w", err)
+			}
+		}
+		return nil
+	})
+	if err == hord.ErrKeyExists {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("error while executing Create transaction - %w", mapError(err))
+	}
+
+	db.watchers.Publish(hord.Event{Type: hord.EventPut, Key: key, Value: data})
+	return nil
+}
+
+// SetWithTTL inserts or updates data in the bbolt database with an expiration. The expiration is stored within
+// a dedicated expiry bucket alongside the data. Once the TTL elapses, the key is no longer returned and will be
+// removed by a background sweeper.
+func (db *Database) SetWithTTL(key string, data []byte, ttl time.Duration) error {
+	if err := hord.ValidKey(key); err != nil {
+		return err
+	}
+
+	if err := hord.ValidData(data); err != nil {
+		return err
+	}
+
+	if err := hord.ValidTTL(ttl); err != nil {
+		return err
+	}
+
+	// Verify DB is connected
+	if db == nil || db.db == nil {
+		return hord.ErrNoDial
+	}
+
+	err := db.db.Update(func(tx *bbolt.Tx) error {
+		// Open Bucket for this Tx
+		bucket := tx.Bucket([]byte(db.cfg.Bucketname))
+		if bucket == nil {
+			return fmt.Errorf("bucket does not exist")
+		}
+
+		// Open Expiry Bucket, creating it for databases setup before TTL support
+		expiry, err := tx.CreateBucketIfNotExists(db.expiryBucket())
+		if err != nil {
+			return fmt.Errorf("unable to open expiry bucket - %w", err)
+		}
+
+		// Store Data into Bucket
+		err = bucket.Put([]byte(key), data)
+		if err != nil {
+			return fmt.Errorf("error while executing SetWithTTL - %w", err)
+		}
+
+		// Store Expiration into Expiry Bucket
+		exp := make([]byte, 8)
+		binary.BigEndian.PutUint64(exp, uint64(time.Now().Add(ttl).UnixNano()))
+		err = expiry.Put([]byte(key), exp)
+		if err != nil {
+			return fmt.Errorf("error while storing expiry - %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("error while executing SetWithTTL transaction - %w", mapError(err))
+	}
+
+	db.sweeper.Do(func() {
+		go db.sweep()
+	})
+
+	db.watchers.Publish(hord.Event{Type: hord.EventPut, Key: key, Value: data})
 	return nil
 }
 
 // Delete removes data from the bbolt database based on the provided key.
 // It returns an error if the key is invalid.
 func (db *Database) Delete(key string) error {
+	return db.DeleteContext(context.Background(), key)
+}
+
+// DeleteContext is a context-aware version of Delete.
+func (db *Database) DeleteContext(ctx context.Context, key string) error {
+	if err := hord.ValidKey(key); err != nil {
+		return err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	// Verify DB is connected
+	if db == nil || db.db == nil {
+		return hord.ErrNoDial
+	}
+
+	var existed bool
+	err := db.db.Update(func(tx *bbolt.Tx) error {
+		// Open Bucket for this Tx
+		bucket := tx.Bucket([]byte(db.cfg.Bucketname))
+		if bucket == nil {
+			return fmt.Errorf("bucket does not exist")
+		}
+
+		// Delete Key
+		existed = bucket.Get([]byte(key)) != nil
+		err := bucket.Delete([]byte(key))
+		if err != nil {
+			return fmt.Errorf("error while executing Delete - %w", err)
+		}
+
+		// Delete Expiration
+		if expiry := tx.Bucket(db.expiryBucket()); expiry != nil {
+			err = expiry.Delete([]byte(key))
+			if err != nil {
+				return fmt.Errorf("error while clearing expiry - %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("error while executing Delete transaction - %w", mapError(err))
+	}
+
+	if existed {
+		db.watchers.Publish(hord.Event{Type: hord.EventDelete, Key: key})
+	}
+	return nil
+}
+
+// GetMany retrieves data for multiple keys from the bbolt database within a single transaction.
+// Keys that do not exist are omitted from the results.
+func (db *Database) GetMany(keys []string) (map[string][]byte, error) {
+	if err := hord.ValidKeys(keys); err != nil {
+		return nil, err
+	}
+
+	// Verify DB is connected
+	if db == nil || db.db == nil {
+		return nil, hord.ErrNoDial
+	}
+
+	results := make(map[string][]byte, len(keys))
+	err := db.db.View(func(tx *bbolt.Tx) error {
+		// Open Bucket for this Tx
+		bucket := tx.Bucket([]byte(db.cfg.Bucketname))
+		if bucket == nil {
+			return fmt.Errorf("bucket does not exist")
+		}
+
+		// Fetch Data from Bucket
+		now := time.Now()
+		for _, k := range keys {
+			d := bucket.Get([]byte(k))
+			if len(d) > 0 && !db.expired(tx, []byte(k), now) {
+				// Copy results as d will only be valid for the lifetime of this Tx
+				results[k] = append([]byte{}, d...)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error while executing GetMany - %w", mapError(err))
+	}
+
+	return results, nil
+}
+
+// SetMany inserts or updates multiple keys in the bbolt database within a single transaction. If any key fails
+// to be written, none of the keys are written.
+func (db *Database) SetMany(items map[string][]byte) error {
+	if err := hord.ValidItems(items); err != nil {
+		return err
+	}
+
+	// Verify DB is connected
+	if db == nil || db.db == nil {
+		return hord.ErrNoDial
+	}
+
+	err := db.db.Update(func(tx *bbolt.Tx) error {
+		// Open Bucket for this Tx
+		bucket := tx.Bucket([]byte(db.cfg.Bucketname))
+		if bucket == nil {
+			return fmt.Errorf("bucket does not exist")
+		}
+		expiry := tx.Bucket(db.expiryBucket())
+
+		// Store Data into Bucket and clear any previous expiration
+		for k, v := range items {
+			err := bucket.Put([]byte(k), v)
+			if err != nil {
+				return fmt.Errorf("error while executing SetMany - %w", err)
+			}
+			if expiry != nil {
+				err = expiry.Delete([]byte(k))
+				if err != nil {
+					return fmt.Errorf("error while clearing expiry - %w", err)
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("error while executing SetMany transaction - %w", mapError(err))
+	}
+
+	for k, v := range items {
+		db.watchers.Publish(hord.Event{Type: hord.EventPut, Key: k, Value: v})
+	}
+	return nil
+}
+
+// DeleteMany removes multiple keys from the bbolt database within a single transaction.
+func (db *Database) DeleteMany(keys []string) error {
+	if err := hord.ValidKeys(keys); err != nil {
+		return err
+	}
+
+	// Verify DB is connected
+	if db == nil || db.db == nil {
+		return hord.ErrNoDial
+	}
+
+	var deleted []string
+	err := db.db.Update(func(tx *bbolt.Tx) error {
+		// Open Bucket for this Tx
+		bucket := tx.Bucket([]byte(db.cfg.Bucketname))
+		if bucket == nil {
+			return fmt.Errorf("bucket does not exist")
+		}
+		expiry := tx.Bucket(db.expiryBucket())
+
+		// Delete Keys and Expirations
+		for _, k := range keys {
+			if bucket.Get([]byte(k)) != nil {
+				deleted = append(deleted, k)
+			}
+			err := bucket.Delete([]byte(k))
+			if err != nil {
+				return fmt.Errorf("error while executing DeleteMany - %w", err)
+			}
+			if expiry != nil {
+				err = expiry.Delete([]byte(k))
+				if err != nil {
+					return fmt.Errorf("error while clearing expiry - %w", err)
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("error while executing DeleteMany transaction - %w", mapError(err))
+	}
+
+	for _, k := range deleted {
+		db.watchers.Publish(hord.Event{Type: hord.EventDelete, Key: k})
+	}
+	return nil
+}
+
+// GetWithVersion retrieves data from the bbolt database along with the key's current Version. Versions are derived
+// from a hash of the stored data.
+func (db *Database) GetWithVersion(key string) ([]byte, hord.Version, error) {
+	data, err := db.Get(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, version(data), nil
+}
+
+// SetIfVersion updates data in the bbolt database only if the key's current Version matches the provided Version.
+// The comparison and update are performed within a single transaction. It returns hord.ErrConflict if the key has
+// been modified or deleted.
+func (db *Database) SetIfVersion(key string, data []byte, v hord.Version) error {
 	if err := hord.ValidKey(key); err != nil {
 		return err
 	}
 
+	if err := hord.ValidData(data); err != nil {
+		return err
+	}
+
+	if err := hord.ValidVersion(v); err != nil {
+		return err
+	}
+
 	// Verify DB is connected
 	if db == nil || db.db == nil {
 		return hord.ErrNoDial
@@ -242,22 +641,104 @@ func (db *Database) Delete(key string) error {
 			return fmt.Errorf("bucket does not exist")
 		}
 
+		// Compare current Version
+		if !db.matches(tx, bucket, []byte(key), v) {
+			return hord.ErrConflict
+		}
+
+		// Store Data into Bucket
+		err := bucket.Put([]byte(key), data)
+		if err != nil {
+			return fmt.Errorf("error while executing SetIfVersion - %w", err)
+		}
+
+		// Clear any previous expiration
+		if expiry := tx.Bucket(db.expiryBucket()); expiry != nil {
+			err = expiry.Delete([]byte(key))
+			if err != nil {
+				return fmt.Errorf("error while clearing expiry - %w", err)
+			}
+		}
+		return nil
+	})
+	if err == hord.ErrConflict {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("error while executing SetIfVersion transaction - %w", mapError(err))
+	}
+
+	db.watchers.Publish(hord.Event{Type: hord.EventPut, Key: key, Value: data})
+	return nil
+}
+
+// DeleteIfVersion removes data from the bbolt database only if the key's current Version matches the provided
+// Version. The comparison and delete are performed within a single transaction. It returns hord.ErrConflict if the
+// key has been modified or deleted.
+func (db *Database) DeleteIfVersion(key string, v hord.Version) error {
+	if err := hord.ValidKey(key); err != nil {
+		return err
+	}
+
+	if err := hord.ValidVersion(v); err != nil {
+		return err
+	}
+
+	// Verify DB is connected
+	if db == nil || db.db == nil {
+		return hord.ErrNoDial
+	}
+
+	err := db.db.Update(func(tx *bbolt.Tx) error {
+		// Open Bucket for this Tx
+		bucket := tx.Bucket([]byte(db.cfg.Bucketname))
+		if bucket == nil {
+			return fmt.Errorf("bucket does not exist")
+		}
+
+		// Compare current Version
+		if !db.matches(tx, bucket, []byte(key), v) {
+			return hord.ErrConflict
+		}
+
 		// Delete Key
 		err := bucket.Delete([]byte(key))
 		if err != nil {
-			return fmt.Errorf("error while executing Delete - %s", err)
+			return fmt.Errorf("error while executing DeleteIfVersion - %w", err)
+		}
+
+		// Delete Expiration
+		if expiry := tx.Bucket(db.expiryBucket()); expiry != nil {
+			err = expiry.Delete([]byte(key))
+			if err != nil {
+				return fmt.Errorf("error while clearing expiry - %w", err)
+			}
 		}
 		return nil
 	})
+	if err == hord.ErrConflict {
+		return err
+	}
 	if err != nil {
-		return fmt.Errorf("error while executing Delete transaction - %s", err)
+		return fmt.Errorf("error while executing DeleteIfVersion transaction - %w", mapError(err))
 	}
 
+	db.watchers.Publish(hord.Event{Type: hord.EventDelete, Key: key})
 	return nil
 }
 
 // Keys retrieves a list of keys stored in the bbolt database.
 func (db *Database) Keys() ([]string, error) {
+	return db.KeysContext(context.Background())
+}
+
+// KeysContext is a context-aware version of Keys. The context is checked between keys, allowing long
+// running key listings to be canceled.
+func (db *Database) KeysContext(ctx context.Context) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	// Verify DB is connected
 	if db == nil || db.db == nil {
 		return nil, hord.ErrNoDial
@@ -272,24 +753,143 @@ func (db *Database) Keys() ([]string, error) {
 		}
 
 		// Loop through keys in bucket and return a list of them
+		now := time.Now()
 		err := bucket.ForEach(func(k, _ []byte) error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if db.expired(tx, k, now) {
+				return nil
+			}
 			keys = append(keys, string(k))
 			return nil
 		})
 		if err != nil {
-			return fmt.Errorf("error while executing Keys - %s", err)
+			return fmt.Errorf("error while executing Keys - %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error while executing Keys transaction - %w", mapError(err))
+	}
+
+	return keys, nil
+}
+
+// KeysWithPrefix retrieves a sorted list of keys that start with the provided prefix. The bucket cursor seeks
+// directly to the prefix, avoiding a scan of the entire bucket.
+func (db *Database) KeysWithPrefix(prefix string) ([]string, error) {
+	// Verify DB is connected
+	if db == nil || db.db == nil {
+		return nil, hord.ErrNoDial
+	}
+
+	var keys []string
+	err := db.db.View(func(tx *bbolt.Tx) error {
+		// Open Bucket for this Tx
+		bucket := tx.Bucket([]byte(db.cfg.Bucketname))
+		if bucket == nil {
+			return fmt.Errorf("bucket does not exist")
+		}
+
+		// Seek to the prefix and walk keys until the prefix no longer matches
+		now := time.Now()
+		p := []byte(prefix)
+		c := bucket.Cursor()
+		for k, _ := c.Seek(p); k != nil && bytes.HasPrefix(k, p); k, _ = c.Next() {
+			if db.expired(tx, k, now) {
+				continue
+			}
+			keys = append(keys, string(k))
 		}
 		return nil
 	})
 	if err != nil {
-		return nil, fmt.Errorf("error while executing Keys transaction - %s", err)
+		return nil, fmt.Errorf("error while executing KeysWithPrefix transaction - %w", mapError(err))
 	}
 
 	return keys, nil
 }
 
+// Range retrieves the keys and data for all keys greater than or equal to start and less than end, sorted by key.
+// An empty end will return all keys from start onward.
+func (db *Database) Range(start, end string) ([]hord.KeyValue, error) {
+	// Verify DB is connected
+	if db == nil || db.db == nil {
+		return nil, hord.ErrNoDial
+	}
+
+	var results []hord.KeyValue
+	err := db.db.View(func(tx *bbolt.Tx) error {
+		// Open Bucket for this Tx
+		bucket := tx.Bucket([]byte(db.cfg.Bucketname))
+		if bucket == nil {
+			return fmt.Errorf("bucket does not exist")
+		}
+
+		// Seek to the start and walk keys until the end is reached
+		now := time.Now()
+		e := []byte(end)
+		c := bucket.Cursor()
+		for k, v := c.Seek([]byte(start)); k != nil; k, v = c.Next() {
+			if len(e) > 0 && bytes.Compare(k, e) >= 0 {
+				break
+			}
+			if db.expired(tx, k, now) {
+				continue
+			}
+			// Copy results as k and v will only be valid for the lifetime of this Tx
+			results = append(results, hord.KeyValue{Key: string(k), Value: append([]byte{}, v...)})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error while executing Range transaction - %w", mapError(err))
+	}
+
+	return results, nil
+}
+
+// Watch returns a channel of events for changes to the provided key and any keys that start with it. Events are
+// published in-process for writes made through this Database instance, including keys removed by the expired key
+// sweeper. The channel is closed when stop is called or the database is closed.
+func (db *Database) Watch(keyOrPrefix string) (<-chan hord.Event, func()) {
+	// Verify DB is connected
+	if db == nil || db.db == nil {
+		ch := make(chan hord.Event)
+		close(ch)
+		return ch, func() {}
+	}
+	return db.watchers.Watch(keyOrPrefix)
+}
+
+// Capabilities returns the optional features supported by the bbolt driver. Every optional feature is supported.
+func (db *Database) Capabilities() hord.Capabilities {
+	return hord.Capabilities{
+		Context:        true,
+		TTL:            true,
+		Batch:          true,
+		Iterate:        true,
+		Prefix:         true,
+		Range:          true,
+		CompareAndSwap: true,
+		Create:         true,
+		Watch:          true,
+		Txn:            true,
+	}
+}
+
 // HealthCheck performs a health check on the bbolt database.
 func (db *Database) HealthCheck() error {
+	return db.HealthCheckContext(context.Background())
+}
+
+// HealthCheckContext is a context-aware version of HealthCheck.
+func (db *Database) HealthCheckContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	// Verify DB is connected
 	if db == nil || db.db == nil {
 		return hord.ErrNoDial
@@ -304,7 +904,7 @@ func (db *Database) HealthCheck() error {
 		return nil
 	})
 	if err != nil {
-		return fmt.Errorf("error while checking database health - %s", err)
+		return fmt.Errorf("error while checking database health - %w", mapError(err))
 	}
 
 	return nil
@@ -317,9 +917,104 @@ func (db *Database) Close() {
 		return
 	}
 
+	// Stop the expired key sweeper
+	db.stopper.Do(func() {
+		close(db.stop)
+	})
+
+	// Stop all watchers
+	db.watchers.Close()
+
 	// Close DB
 	err := db.db.Close()
 	if err != nil {
 		return
 	}
 }
+
+// expiryBucket returns the name of the bucket used to store key expirations.
+func (db *Database) expiryBucket() []byte {
+	return []byte(db.cfg.Bucketname + "_hord_expiry")
+}
+
+// expired returns true if the key has a TTL that has elapsed.
+func (db *Database) expired(tx *bbolt.Tx, key []byte, now time.Time) bool {
+	expiry := tx.Bucket(db.expiryBucket())
+	if expiry == nil {
+		return false
+	}
+
+	exp := expiry.Get(key)
+	if len(exp) != 8 {
+		return false
+	}
+	return now.UnixNano() >= int64(binary.BigEndian.Uint64(exp))
+}
+
+// matches returns true if the key exists, has not expired, and the Version of its data matches the provided Version.
+func (db *Database) matches(tx *bbolt.Tx, bucket *bbolt.Bucket, key []byte, v hord.Version) bool {
+	d := bucket.Get(key)
+	if d == nil || db.expired(tx, key, time.Now()) {
+		return false
+	}
+	return bytes.Equal(version(d), v)
+}
+
+// version returns a Version derived from the SHA-256 hash of the provided data.
+func version(data []byte) hord.Version {
+	sum := sha256.Sum256(data)
+	return sum[:]
+}
+
+// sweep periodically removes expired keys from the database until the database is closed.
+func (db *Database) sweep() {
+	ticker := time.NewTicker(db.cfg.SweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-db.stop:
+			return
+		case <-ticker.C:
+			// Errors are ignored as sweeping will be retried on the next interval
+			var expired [][]byte
+			err := db.db.Update(func(tx *bbolt.Tx) error {
+				bucket := tx.Bucket([]byte(db.cfg.Bucketname))
+				expiry := tx.Bucket(db.expiryBucket())
+				if bucket == nil || expiry == nil {
+					return nil
+				}
+
+				// Collect expired keys, as keys cannot be deleted while iterating
+				now := time.Now()
+				expired = nil
+				err := expiry.ForEach(func(k, _ []byte) error {
+					if db.expired(tx, k, now) {
+						expired = append(expired, append([]byte{}, k...))
+					}
+					return nil
+				})
+				if err != nil {
+					return err
+				}
+
+				for _, k := range expired {
+					if err := bucket.Delete(k); err != nil {
+						return err
+					}
+					if err := expiry.Delete(k); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+			if err != nil {
+				continue
+			}
+
+			for _, k := range expired {
+				db.watchers.Publish(hord.Event{Type: hord.EventDelete, Key: string(k)})
+			}
+		}
+	}
+}
@@ -1,10 +1,14 @@
 package bbolt
 
 import (
+	"errors"
 	"os"
 	"strconv"
 	"testing"
 	"time"
+
+	"github.com/madflojo/hord"
+	"go.etcd.io/bbolt"
 )
 
 type TestCase struct {
@@ -112,3 +116,695 @@ func TestBBoltConnectivity(t *testing.T) {
 		})
 	}
 }
+
+func TestTTL(t *testing.T) {
+	// Create Directory for Test Execution
+	tmpDir := "/tmp/" + TmpFn()
+	err := os.Mkdir(tmpDir, 0750)
+	if err != nil {
+		t.Fatalf("Unable to create test directory - %s", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	db, err := Dial(Config{
+		Bucketname:    "test",
+		Filename:      tmpDir + "/" + TmpFn() + "ttl",
+		SweepInterval: 10 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("unexpected failure while Dialing database - %s", err)
+	}
+	defer db.Close()
+
+	err = db.Setup()
+	if err != nil {
+		t.Fatalf("unexpected failure while Setting up database - %s", err)
+	}
+
+	t.Run("Invalid TTL", func(t *testing.T) {
+		err := db.SetWithTTL("key", []byte("value"), 0)
+		if !errors.Is(err, hord.ErrInvalidTTL) {
+			t.Errorf("unexpected error - %s", err)
+		}
+	})
+
+	t.Run("Key Expires", func(t *testing.T) {
+		err := db.SetWithTTL("expiring", []byte("value"), 50*time.Millisecond)
+		if err != nil {
+			t.Fatalf("unexpected error - %s", err)
+		}
+
+		value, err := db.Get("expiring")
+		if err != nil || string(value) != "value" {
+			t.Fatalf("unexpected value %s, error - %s", value, err)
+		}
+
+		<-time.After(100 * time.Millisecond)
+
+		_, err = db.Get("expiring")
+		if !errors.Is(err, hord.ErrNil) {
+			t.Errorf("expected ErrNil after TTL elapsed, got - %s", err)
+		}
+
+		keys, err := db.Keys()
+		if err != nil || len(keys) != 0 {
+			t.Errorf("unexpected keys %v, error - %s", keys, err)
+		}
+	})
+
+	t.Run("Set Clears TTL", func(t *testing.T) {
+		err := db.SetWithTTL("persist", []byte("value"), 50*time.Millisecond)
+		if err != nil {
+			t.Fatalf("unexpected error - %s", err)
+		}
+
+		err = db.Set("persist", []byte("value"))
+		if err != nil {
+			t.Fatalf("unexpected error - %s", err)
+		}
+
+		<-time.After(100 * time.Millisecond)
+
+		value, err := db.Get("persist")
+		if err != nil || string(value) != "value" {
+			t.Errorf("unexpected value %s, error - %s", value, err)
+		}
+	})
+}
+
+func TestTTLAfterReopen(t *testing.T) {
+	// Create Directory for Test Execution
+	tmpDir := "/tmp/" + TmpFn()
+	err := os.Mkdir(tmpDir, 0750)
+	if err != nil {
+		t.Fatalf("Unable to create test directory - %s", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	cfg := Config{
+		Bucketname:    "test",
+		Filename:      tmpDir + "/" + TmpFn() + "ttl-reopen",
+		SweepInterval: 10 * time.Millisecond,
+	}
+
+	db, err := Dial(cfg)
+	if err != nil {
+		t.Fatalf("unexpected failure while Dialing database - %s", err)
+	}
+	err = db.Setup()
+	if err != nil {
+		t.Fatalf("unexpected failure while Setting up database - %s", err)
+	}
+	err = db.SetWithTTL("expiring", []byte("value"), 50*time.Millisecond)
+	if err != nil {
+		t.Fatalf("unexpected error - %s", err)
+	}
+	db.Close()
+
+	db, err = Dial(cfg)
+	if err != nil {
+		t.Fatalf("unexpected failure while Dialing database - %s", err)
+	}
+	defer db.Close()
+	err = db.Setup()
+	if err != nil {
+		t.Fatalf("unexpected failure while Setting up database - %s", err)
+	}
+
+	<-time.After(200 * time.Millisecond)
+
+	// The sweeper must remove the key without a new call to SetWithTTL
+	err = db.db.View(func(tx *bbolt.Tx) error {
+		if v := tx.Bucket([]byte(cfg.Bucketname)).Get([]byte("expiring")); v != nil {
+			t.Errorf("expected expired key to be swept after re-open")
+		}
+		if v := tx.Bucket(db.expiryBucket()).Get([]byte("expiring")); v != nil {
+			t.Errorf("expected expiry to be swept after re-open")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error - %s", err)
+	}
+}
+
+func TestBatch(t *testing.T) {
+	// Create Directory for Test Execution
+	tmpDir := "/tmp/" + TmpFn()
+	err := os.Mkdir(tmpDir, 0750)
+	if err != nil {
+		t.Fatalf("Unable to create test directory - %s", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	db, err := Dial(Config{
+		Bucketname: "test",
+		Filename:   tmpDir + "/" + TmpFn() + "batch",
+	})
+	if err != nil {
+		t.Fatalf("unexpected failure while Dialing database - %s", err)
+	}
+	defer db.Close()
+
+	err = db.Setup()
+	if err != nil {
+		t.Fatalf("unexpected failure while Setting up database - %s", err)
+	}
+
+	t.Run("Invalid Input", func(t *testing.T) {
+		if _, err := db.GetMany([]string{""}); !errors.Is(err, hord.ErrInvalidKey) {
+			t.Errorf("unexpected error - %s", err)
+		}
+		if err := db.SetMany(map[string][]byte{"key": nil}); !errors.Is(err, hord.ErrInvalidData) {
+			t.Errorf("unexpected error - %s", err)
+		}
+		if err := db.DeleteMany([]string{""}); !errors.Is(err, hord.ErrInvalidKey) {
+			t.Errorf("unexpected error - %s", err)
+		}
+	})
+
+	t.Run("Set Get Delete", func(t *testing.T) {
+		err := db.SetMany(map[string][]byte{"a": []byte("1"), "b": []byte("2")})
+		if err != nil {
+			t.Fatalf("unexpected error - %s", err)
+		}
+
+		results, err := db.GetMany([]string{"a", "b", "missing"})
+		if err != nil {
+			t.Fatalf("unexpected error - %s", err)
+		}
+		if len(results) != 2 || string(results["a"]) != "1" || string(results["b"]) != "2" {
+			t.Errorf("unexpected results - %v", results)
+		}
+
+		err = db.DeleteMany([]string{"a", "b"})
+		if err != nil {
+			t.Fatalf("unexpected error - %s", err)
+		}
+
+		keys, err := db.Keys()
+		if err != nil || len(keys) != 0 {
+			t.Errorf("unexpected keys %v, error - %s", keys, err)
+		}
+	})
+}
+
+func TestIterateKeys(t *testing.T) {
+	// Create Directory for Test Execution
+	tmpDir := "/tmp/" + TmpFn()
+	err := os.Mkdir(tmpDir, 0750)
+	if err != nil {
+		t.Fatalf("Unable to create test directory - %s", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	db, err := Dial(Config{
+		Bucketname: "test",
+		Filename:   tmpDir + "/" + TmpFn() + "iterate",
+	})
+	if err != nil {
+		t.Fatalf("unexpected failure while Dialing database - %s", err)
+	}
+	defer db.Close()
+
+	err = db.Setup()
+	if err != nil {
+		t.Fatalf("unexpected failure while Setting up database - %s", err)
+	}
+
+	// Create enough keys to span multiple batches
+	items := make(map[string][]byte)
+	for i := 0; i < batchSize*2+500; i++ {
+		items["key"+strconv.Itoa(i)] = []byte("value")
+	}
+	err = db.SetMany(items)
+	if err != nil {
+		t.Fatalf("unexpected error - %s", err)
+	}
+
+	iter, err := db.IterateKeys()
+	if err != nil {
+		t.Fatalf("unexpected error - %s", err)
+	}
+	defer iter.Close()
+
+	seen := make(map[string]bool)
+	last := ""
+	for iter.Next() {
+		k := iter.Key()
+		if seen[k] {
+			t.Errorf("key %s returned more than once", k)
+		}
+		if k <= last {
+			t.Errorf("key %s returned out of order after %s", k, last)
+		}
+		seen[k] = true
+		last = k
+	}
+	if err := iter.Err(); err != nil {
+		t.Errorf("unexpected error - %s", err)
+	}
+	if len(seen) != len(items) {
+		t.Errorf("expected %d keys, got %d", len(items), len(seen))
+	}
+}
+
+func TestPrefixAndRange(t *testing.T) {
+	// Create Directory for Test Execution
+	tmpDir := "/tmp/" + TmpFn()
+	err := os.Mkdir(tmpDir, 0750)
+	if err != nil {
+		t.Fatalf("Unable to create test directory - %s", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	db, err := Dial(Config{
+		Bucketname: "test",
+		Filename:   tmpDir + "/" + TmpFn() + "prefix",
+	})
+	if err != nil {
+		t.Fatalf("unexpected failure while Dialing database - %s", err)
+	}
+	defer db.Close()
+
+	err = db.Setup()
+	if err != nil {
+		t.Fatalf("unexpected failure while Setting up database - %s", err)
+	}
+
+	err = db.SetMany(map[string][]byte{
+		"tenant:1:user:1": []byte("1"),
+		"tenant:1:user:2": []byte("2"),
+		"tenant:2:user:1": []byte("3"),
+		"tenant:3:user:1": []byte("4"),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error - %s", err)
+	}
+
+	t.Run("Keys With Prefix", func(t *testing.T) {
+		keys, err := db.KeysWithPrefix("tenant:1:")
+		if err != nil {
+			t.Fatalf("unexpected error - %s", err)
+		}
+		if len(keys) != 2 || keys[0] != "tenant:1:user:1" || keys[1] != "tenant:1:user:2" {
+			t.Errorf("unexpected keys - %v", keys)
+		}
+	})
+
+	t.Run("Range", func(t *testing.T) {
+		results, err := db.Range("tenant:1:user:2", "tenant:3:")
+		if err != nil {
+			t.Fatalf("unexpected error - %s", err)
+		}
+		if len(results) != 2 || results[0].Key != "tenant:1:user:2" || string(results[1].Value) != "3" {
+			t.Errorf("unexpected results - %+v", results)
+		}
+	})
+
+	t.Run("Range Open Ended", func(t *testing.T) {
+		results, err := db.Range("tenant:2:", "")
+		if err != nil || len(results) != 2 {
+			t.Errorf("unexpected results %+v, error - %s", results, err)
+		}
+	})
+}
+
+func TestVersion(t *testing.T) {
+	// Create Directory for Test Execution
+	tmpDir := "/tmp/" + TmpFn()
+	err := os.Mkdir(tmpDir, 0750)
+	if err != nil {
+		t.Fatalf("Unable to create test directory - %s", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	db, err := Dial(Config{
+		Bucketname: "test",
+		Filename:   tmpDir + "/" + TmpFn() + "version",
+	})
+	if err != nil {
+		t.Fatalf("unexpected failure while Dialing database - %s", err)
+	}
+	defer db.Close()
+
+	err = db.Setup()
+	if err != nil {
+		t.Fatalf("unexpected failure while Setting up database - %s", err)
+	}
+
+	if err := db.Set("cas", []byte("v1")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data, version, err := db.GetWithVersion("cas")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(data) != "v1" {
+		t.Errorf("unexpected data: %s", data)
+	}
+
+	t.Run("SetIfVersion", func(t *testing.T) {
+		if err := db.SetIfVersion("cas", []byte("v2"), version); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		err := db.SetIfVersion("cas", []byte("v3"), version)
+		if !errors.Is(err, hord.ErrConflict) {
+			t.Errorf("expected ErrConflict using stale version, got %v", err)
+		}
+
+		data, err := db.Get("cas")
+		if err != nil || string(data) != "v2" {
+			t.Errorf("unexpected data: %s, error: %v", data, err)
+		}
+	})
+
+	t.Run("DeleteIfVersion", func(t *testing.T) {
+		_, current, err := db.GetWithVersion("cas")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if err := db.DeleteIfVersion("cas", version); !errors.Is(err, hord.ErrConflict) {
+			t.Errorf("expected ErrConflict using stale version, got %v", err)
+		}
+
+		if err := db.DeleteIfVersion("cas", current); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if _, err := db.Get("cas"); !errors.Is(err, hord.ErrNil) {
+			t.Errorf("expected ErrNil after DeleteIfVersion, got %v", err)
+		}
+	})
+
+	t.Run("Missing Key", func(t *testing.T) {
+		if err := db.SetIfVersion("cas", []byte("v4"), version); !errors.Is(err, hord.ErrConflict) {
+			t.Errorf("expected ErrConflict for deleted key, got %v", err)
+		}
+	})
+
+	t.Run("Invalid Version", func(t *testing.T) {
+		if err := db.SetIfVersion("cas", []byte("v2"), nil); !errors.Is(err, hord.ErrInvalidVersion) {
+			t.Errorf("expected ErrInvalidVersion, got %v", err)
+		}
+	})
+}
+
+func TestCreate(t *testing.T) {
+	// Create Directory for Test Execution
+	tmpDir := "/tmp/" + TmpFn()
+	err := os.Mkdir(tmpDir, 0750)
+	if err != nil {
+		t.Fatalf("Unable to create test directory - %s", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	db, err := Dial(Config{
+		Bucketname: "test",
+		Filename:   tmpDir + "/" + TmpFn() + "create",
+	})
+	if err != nil {
+		t.Fatalf("unexpected failure while Dialing database - %s", err)
+	}
+	defer db.Close()
+
+	err = db.Setup()
+	if err != nil {
+		t.Fatalf("unexpected failure while Setting up database - %s", err)
+	}
+
+	if err := db.Create("claim", []byte("a")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := db.Create("claim", []byte("b")); !errors.Is(err, hord.ErrKeyExists) {
+		t.Errorf("expected ErrKeyExists, got %v", err)
+	}
+
+	data, err := db.Get("claim")
+	if err != nil || string(data) != "a" {
+		t.Errorf("unexpected data: %s, error: %v", data, err)
+	}
+
+	t.Run("Expired Key", func(t *testing.T) {
+		if err := db.SetWithTTL("expiring", []byte("a"), time.Millisecond); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		<-time.After(5 * time.Millisecond)
+
+		if err := db.Create("expiring", []byte("b")); err != nil {
+			t.Errorf("unexpected error creating expired key: %v", err)
+		}
+	})
+}
+
+func TestWatch(t *testing.T) {
+	// Create Directory for Test Execution
+	tmpDir := "/tmp/" + TmpFn()
+	err := os.Mkdir(tmpDir, 0750)
+	if err != nil {
+		t.Fatalf("Unable to create test directory - %s", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	db, err := Dial(Config{
+		Bucketname:    "test",
+		Filename:      tmpDir + "/" + TmpFn() + "watch",
+		SweepInterval: 10 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("unexpected failure while Dialing database - %s", err)
+	}
+	defer db.Close()
+
+	err = db.Setup()
+	if err != nil {
+		t.Fatalf("unexpected failure while Setting up database - %s", err)
+	}
+
+	events, stop := db.Watch("config:")
+	defer stop()
+
+	if err := db.Set("other", []byte("1")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := db.Set("config:a", []byte("2")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := db.Delete("config:a"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := db.Delete("config:missing"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := db.SetWithTTL("config:b", []byte("3"), time.Millisecond); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, want := range []hord.Event{
+		{Type: hord.EventPut, Key: "config:a", Value: []byte("2")},
+		{Type: hord.EventDelete, Key: "config:a"},
+		{Type: hord.EventPut, Key: "config:b", Value: []byte("3")},
+		{Type: hord.EventDelete, Key: "config:b"},
+	} {
+		select {
+		case e := <-events:
+			if e.Type != want.Type || e.Key != want.Key || string(e.Value) != string(want.Value) {
+				t.Errorf("expected event %+v, got %+v", want, e)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for event %+v", want)
+		}
+	}
+
+	t.Run("Closed", func(t *testing.T) {
+		db.Close()
+
+		select {
+		case _, ok := <-events:
+			if ok {
+				t.Errorf("expected channel to be closed")
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for channel to close")
+		}
+	})
+}
+
+func TestTxn(t *testing.T) {
+	// Create Directory for Test Execution
+	tmpDir := "/tmp/" + TmpFn()
+	err := os.Mkdir(tmpDir, 0750)
+	if err != nil {
+		t.Fatalf("Unable to create test directory - %s", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	db, err := Dial(Config{
+		Bucketname: "test",
+		Filename:   tmpDir + "/" + TmpFn() + "txn",
+	})
+	if err != nil {
+		t.Fatalf("unexpected failure while Dialing database - %s", err)
+	}
+	defer db.Close()
+
+	err = db.Setup()
+	if err != nil {
+		t.Fatalf("unexpected failure while Setting up database - %s", err)
+	}
+
+	if err := db.Set("stale", []byte("1")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	t.Run("Commit", func(t *testing.T) {
+		err := db.Txn(func(tx hord.Tx) error {
+			if err := tx.Set("record:1", []byte("data")); err != nil {
+				return err
+			}
+			if err := tx.Set("index:1", []byte("record:1")); err != nil {
+				return err
+			}
+			if err := tx.Delete("stale"); err != nil {
+				return err
+			}
+
+			// Writes are visible within the transaction
+			if d, err := tx.Get("record:1"); err != nil || string(d) != "data" {
+				t.Errorf("unexpected data within transaction: %s, error: %v", d, err)
+			}
+			if _, err := tx.Get("stale"); !errors.Is(err, hord.ErrNil) {
+				t.Errorf("expected ErrNil for deleted key within transaction, got %v", err)
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		results, err := db.GetMany([]string{"record:1", "index:1", "stale"})
+		if err != nil || len(results) != 2 {
+			t.Errorf("unexpected results: %v, error: %v", results, err)
+		}
+	})
+
+	t.Run("Rollback", func(t *testing.T) {
+		errFail := errors.New("fail")
+		err := db.Txn(func(tx hord.Tx) error {
+			if err := tx.Set("record:2", []byte("data")); err != nil {
+				return err
+			}
+			return errFail
+		})
+		if !errors.Is(err, errFail) {
+			t.Fatalf("expected transaction error, got %v", err)
+		}
+
+		if _, err := db.Get("record:2"); !errors.Is(err, hord.ErrNil) {
+			t.Errorf("expected ErrNil for rolled back key, got %v", err)
+		}
+	})
+}
+
+func TestCapabilities(t *testing.T) {
+	expected := hord.Capabilities{
+		Context:        true,
+		TTL:            true,
+		Batch:          true,
+		Iterate:        true,
+		Prefix:         true,
+		Range:          true,
+		CompareAndSwap: true,
+		Create:         true,
+		Watch:          true,
+		Txn:            true,
+	}
+	if c := hord.CapabilitiesOf(&Database{}); c != expected {
+		t.Errorf("unexpected capabilities: %+v", c)
+	}
+}
+
+func TestParseDSN(t *testing.T) {
+	cfg, err := ParseDSN("bbolt:///tmp/data.db?bucket=app&permissions=0600&timeout=2s&sweep_interval=30s")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := Config{
+		Bucketname:    "app",
+		Filename:      "/tmp/data.db",
+		Permissions:   0600,
+		Timeout:       2 * time.Second,
+		SweepInterval: 30 * time.Second,
+	}
+	if cfg != expected {
+		t.Errorf("unexpected config: %+v", cfg)
+	}
+
+	cfg, err = ParseDSN("bbolt://./data.db?bucket=app")
+	if err != nil || cfg.Filename != "./data.db" {
+		t.Errorf("unexpected config: %+v, error: %v", cfg, err)
+	}
+
+	for _, dsn := range []string{
+		"hashmap:///tmp/data.db",
+		"bbolt:///tmp/data.db?permissions=rw",
+		"bbolt:///tmp/data.db?timeout=soon",
+		"bbolt:///tmp/data.db?unknown=1",
+	} {
+		if _, err := ParseDSN(dsn); err == nil {
+			t.Errorf("expected error parsing %s", dsn)
+		}
+	}
+}
+
+func TestMapError(t *testing.T) {
+	tc := map[string]struct {
+		err  error
+		want error
+	}{
+		"Not Open":    {err: bbolt.ErrDatabaseNotOpen, want: hord.ErrClosed},
+		"Timeout":     {err: bbolt.ErrTimeout, want: hord.ErrTimeout},
+		"Too Large":   {err: bbolt.ErrValueTooLarge, want: hord.ErrValueTooLarge},
+		"Bucket Name": {err: bbolt.ErrBucketNameRequired, want: nil},
+	}
+
+	for name, c := range tc {
+		t.Run(name, func(t *testing.T) {
+			err := mapError(c.err)
+			if !errors.Is(err, c.err) {
+				t.Errorf("Expected original error to be preserved, got %s", err)
+			}
+			if c.want == nil {
+				if err != c.err {
+					t.Errorf("Expected error to be returned unchanged, got %s", err)
+				}
+				return
+			}
+			if !errors.Is(err, c.want) {
+				t.Errorf("Expected %s, got %s", c.want, err)
+			}
+		})
+	}
+
+	t.Run("Closed Database", func(t *testing.T) {
+		filename := "/tmp/" + TmpFn() + "maperror"
+		defer os.Remove(filename)
+
+		db, err := Dial(Config{Bucketname: "test", Filename: filename})
+		if err != nil {
+			t.Fatalf("Unexpected error dialing database - %s", err)
+		}
+		db.Close()
+
+		if err := db.Set("key", []byte("value")); !errors.Is(err, hord.ErrClosed) {
+			t.Errorf("Expected ErrClosed, got %v", err)
+		}
+		if _, err := db.Get("key"); !errors.Is(err, hord.ErrClosed) {
+			t.Errorf("Expected ErrClosed, got %v", err)
+		}
+	})
+}
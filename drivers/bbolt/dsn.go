@@ -0,0 +1,78 @@
+package bbolt
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/madflojo/hord"
+)
+
+func init() {
+	hord.Register("bbolt", open)
+}
+
+// open is the hord.Factory for the bbolt driver.
+func open(dsn string) (hord.Database, error) {
+	cfg, err := ParseDSN(dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := Dial(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return db, nil
+}
+
+// ParseDSN parses a bbolt URL into a Config. The remainder of the URL after the scheme is the database file path;
+// use three slashes for absolute paths.
+//
+//	bbolt:///var/lib/app/data.db?bucket=app&timeout=5s
+//	bbolt://./data.db?bucket=app
+//
+// Supported query parameters are bucket, permissions (octal), timeout, and sweep_interval.
+func ParseDSN(dsn string) (Config, error) {
+	var cfg Config
+
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return cfg, fmt.Errorf("unable to parse DSN - %w", err)
+	}
+
+	if u.Scheme != "bbolt" {
+		return cfg, fmt.Errorf("unsupported DSN scheme %q", u.Scheme)
+	}
+
+	cfg.Filename = u.Opaque
+	if cfg.Filename == "" {
+		cfg.Filename = u.Host + u.Path
+	}
+
+	q := u.Query()
+	for k := range q {
+		v := q.Get(k)
+		switch k {
+		case "bucket":
+			cfg.Bucketname = v
+		case "permissions":
+			var perm uint64
+			perm, err = strconv.ParseUint(v, 8, 32)
+			cfg.Permissions = os.FileMode(perm)
+		case "timeout":
+			cfg.Timeout, err = time.ParseDuration(v)
+		case "sweep_interval":
+			cfg.SweepInterval, err = time.ParseDuration(v)
+		default:
+			return cfg, fmt.Errorf("unknown DSN parameter %q", k)
+		}
+		if err != nil {
+			return cfg, fmt.Errorf("invalid DSN parameter %q - %w", k, err)
+		}
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,25 @@
+package bbolt
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/madflojo/hord"
+	"go.etcd.io/bbolt"
+)
+
+// mapError maps errors returned by bbolt onto the common Hord errors, keeping the original error within the chain.
+// Errors without a Hord equivalent are returned unchanged.
+func mapError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, bbolt.ErrDatabaseNotOpen), errors.Is(err, bbolt.ErrTxClosed):
+		return fmt.Errorf("%w: %w", hord.ErrClosed, err)
+	case errors.Is(err, bbolt.ErrTimeout):
+		return fmt.Errorf("%w: %w", hord.ErrTimeout, err)
+	case errors.Is(err, bbolt.ErrValueTooLarge):
+		return fmt.Errorf("%w: %w", hord.ErrValueTooLarge, err)
+	}
+	return err
+}
@@ -0,0 +1,118 @@
+package bbolt
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/madflojo/hord"
+	"go.etcd.io/bbolt"
+)
+
+// batchSize is the number of keys read from the bucket within each read transaction.
+const batchSize = 1000
+
+// Iterator walks the keys stored within a bbolt bucket using cursors. Keys are read in batches, each within its own
+// short read transaction, so iterating does not hold a transaction open for the lifetime of the Iterator. Keys are
+// returned in byte-sorted order.
+type Iterator struct {
+	// db is the Database being iterated
+	db *Database
+
+	// last is the last key read, used to seek to the next batch
+	last []byte
+
+	// started is set once the first batch has been read
+	started bool
+
+	// done is set once the end of the bucket is reached
+	done bool
+
+	// keys holds the current batch of keys
+	keys []string
+
+	// key is the current key
+	key string
+
+	// err holds any error encountered while iterating
+	err error
+}
+
+// IterateKeys returns an Iterator that walks all keys stored within the bbolt bucket.
+func (db *Database) IterateKeys() (hord.Iterator, error) {
+	// Verify DB is connected
+	if db == nil || db.db == nil {
+		return nil, hord.ErrNoDial
+	}
+	return &Iterator{db: db}, nil
+}
+
+// Next advances the iterator, reading the next batch of keys when needed.
+func (i *Iterator) Next() bool {
+	for len(i.keys) == 0 {
+		if i.done || i.err != nil || i.db == nil {
+			return false
+		}
+		i.read()
+	}
+
+	i.key = i.keys[0]
+	i.keys = i.keys[1:]
+	return true
+}
+
+// read fetches the next batch of keys from the bucket.
+func (i *Iterator) read() {
+	err := i.db.db.View(func(tx *bbolt.Tx) error {
+		// Open Bucket for this Tx
+		bucket := tx.Bucket([]byte(i.db.cfg.Bucketname))
+		if bucket == nil {
+			return fmt.Errorf("bucket does not exist")
+		}
+
+		c := bucket.Cursor()
+		k, _ := c.First()
+		if i.started {
+			// Seek to the last key read and skip past it
+			k, _ = c.Seek(i.last)
+			if k != nil && bytes.Equal(k, i.last) {
+				k, _ = c.Next()
+			}
+		}
+		i.started = true
+
+		now := time.Now()
+		for ; k != nil && len(i.keys) < batchSize; k, _ = c.Next() {
+			// Copy key as k will only be valid for the lifetime of this Tx
+			i.last = append(i.last[:0], k...)
+			if i.db.expired(tx, k, now) {
+				continue
+			}
+			i.keys = append(i.keys, string(k))
+		}
+		if k == nil {
+			i.done = true
+		}
+		return nil
+	})
+	if err != nil {
+		i.err = fmt.Errorf("error while iterating keys - %w", mapError(err))
+	}
+}
+
+// Key returns the current key.
+func (i *Iterator) Key() string {
+	return i.key
+}
+
+// Err returns any error encountered while iterating.
+func (i *Iterator) Err() error {
+	return i.err
+}
+
+// Close stops the iterator. Transactions are only held while reading a batch, so there is nothing to release.
+func (i *Iterator) Close() error {
+	i.db = nil
+	i.keys = nil
+	return nil
+}
@@ -0,0 +1,131 @@
+package bbolt
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/madflojo/hord"
+	"go.etcd.io/bbolt"
+)
+
+// Txn executes fn within a bbolt read-write transaction. Writes are committed atomically if fn returns nil and rolled
+// back otherwise. bbolt allows a single read-write transaction at a time; Database methods must not be called from
+// within fn.
+func (db *Database) Txn(fn func(tx hord.Tx) error) error {
+	// Verify DB is connected
+	if db == nil || db.db == nil {
+		return hord.ErrNoDial
+	}
+
+	var fnErr error
+	var events []hord.Event
+	err := db.db.Update(func(btx *bbolt.Tx) error {
+		// Open Bucket for this Tx
+		bucket := btx.Bucket([]byte(db.cfg.Bucketname))
+		if bucket == nil {
+			return fmt.Errorf("bucket does not exist")
+		}
+
+		tx := &txn{db: db, tx: btx, bucket: bucket, expiry: btx.Bucket(db.expiryBucket())}
+		fnErr = fn(tx)
+		if fnErr != nil {
+			return fnErr
+		}
+		events = tx.events
+		return nil
+	})
+	if fnErr != nil {
+		return fnErr
+	}
+	if err != nil {
+		return fmt.Errorf("error while executing Txn transaction - %w", mapError(err))
+	}
+
+	for _, e := range events {
+		db.watchers.Publish(e)
+	}
+	return nil
+}
+
+// txn is the bbolt implementation of hord.Tx.
+type txn struct {
+	db *Database
+
+	// tx is the underlying bbolt transaction
+	tx *bbolt.Tx
+
+	// bucket is the data bucket for this transaction
+	bucket *bbolt.Bucket
+
+	// expiry is the expiry bucket for this transaction, nil if it does not exist
+	expiry *bbolt.Bucket
+
+	// events holds the events published once the transaction commits
+	events []hord.Event
+}
+
+// Get retrieves data within the transaction, including data written earlier in the same transaction.
+func (tx *txn) Get(key string) ([]byte, error) {
+	if err := hord.ValidKey(key); err != nil {
+		return nil, err
+	}
+
+	d := tx.bucket.Get([]byte(key))
+	if d == nil || tx.db.expired(tx.tx, []byte(key), time.Now()) {
+		return nil, hord.ErrNil
+	}
+
+	// Copy results as d will only be valid for the lifetime of this Tx
+	return append([]byte{}, d...), nil
+}
+
+// Set writes data within the transaction, clearing any previous expiration.
+func (tx *txn) Set(key string, data []byte) error {
+	if err := hord.ValidKey(key); err != nil {
+		return err
+	}
+
+	if err := hord.ValidData(data); err != nil {
+		return err
+	}
+
+	err := tx.bucket.Put([]byte(key), data)
+	if err != nil {
+		return fmt.Errorf("error while executing Set - %w", err)
+	}
+
+	if tx.expiry != nil {
+		err = tx.expiry.Delete([]byte(key))
+		if err != nil {
+			return fmt.Errorf("error while clearing expiry - %w", err)
+		}
+	}
+
+	tx.events = append(tx.events, hord.Event{Type: hord.EventPut, Key: key, Value: data})
+	return nil
+}
+
+// Delete removes the key and its expiration within the transaction.
+func (tx *txn) Delete(key string) error {
+	if err := hord.ValidKey(key); err != nil {
+		return err
+	}
+
+	existed := tx.bucket.Get([]byte(key)) != nil
+	err := tx.bucket.Delete([]byte(key))
+	if err != nil {
+		return fmt.Errorf("error while executing Delete - %w", err)
+	}
+
+	if tx.expiry != nil {
+		err = tx.expiry.Delete([]byte(key))
+		if err != nil {
+			return fmt.Errorf("error while clearing expiry - %w", err)
+		}
+	}
+
+	if existed {
+		tx.events = append(tx.events, hord.Event{Type: hord.EventDelete, Key: key})
+	}
+	return nil
+}
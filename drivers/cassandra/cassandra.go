@@ -57,9 +57,11 @@ Hord provides a simple abstraction for working with Cassandra, with easy-to-use
 package cassandra
 
 import (
+	"context"
 	"fmt"
 	"github.com/gocql/gocql"
 	"github.com/madflojo/hord"
+	"time"
 )
 
 // Config is a generic configuration that is passed when Dialing the Cassandra cluster.
@@ -152,7 +154,7 @@ func Dial(conf Config) (*Database, error) {
 	// Setup new session
 	session, err := cluster.CreateSession()
 	if err != nil {
-		return nil, err
+		return nil, mapError(err)
 	}
 	db.conn = session
 
@@ -163,6 +165,11 @@ func Dial(conf Config) (*Database, error) {
 // already been initialized this function will not execute but return with a nil error. If any issues occur
 // while initializing an error will be returned.
 func (db *Database) Setup() error {
+	return db.SetupContext(context.Background())
+}
+
+// SetupContext is a context-aware version of Setup.
+func (db *Database) SetupContext(ctx context.Context) error {
 	if db == nil || db.conn == nil {
 		return hord.ErrNoDial
 	}
@@ -170,7 +177,7 @@ func (db *Database) Setup() error {
 
 	// If keyspace exists and there was an error dip out with an err
 	if err != nil && err != gocql.ErrNoKeyspace {
-		return fmt.Errorf("unable to initialize database, failed keystore validation - %s", err)
+		return fmt.Errorf("unable to initialize database, failed keystore validation - %w", mapError(err))
 	}
 
 	// If keyspace doesn't exist, let's get creating
@@ -179,9 +186,9 @@ func (db *Database) Setup() error {
 			db.config.Keyspace,
 			db.config.ReplicationStrategy,
 			db.config.Replicas)
-		err := db.conn.Query(qry).Exec()
+		err := db.conn.Query(qry).WithContext(ctx).Exec()
 		if err != nil {
-			return fmt.Errorf("unable to initialize database, failed to create keystore - %s", err)
+			return fmt.Errorf("unable to initialize database, failed to create keystore - %w", mapError(err))
 		}
 	}
 
@@ -191,9 +198,9 @@ func (db *Database) Setup() error {
 	}
 	qry := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s.hord ( key text, data blob, PRIMARY KEY (key));",
 		db.config.Keyspace)
-	err = db.conn.Query(qry).Exec()
+	err = db.conn.Query(qry).WithContext(ctx).Exec()
 	if err != nil {
-		return fmt.Errorf("unable to initialize database, failed to create table - %s", err)
+		return fmt.Errorf("unable to initialize database, failed to create table - %w", mapError(err))
 	}
 
 	return nil
@@ -202,6 +209,11 @@ func (db *Database) Setup() error {
 // Get is called to retrieve data from the database. This function will take in a key and return
 // the data or any errors received from querying the database.
 func (db *Database) Get(key string) ([]byte, error) {
+	return db.GetContext(context.Background(), key)
+}
+
+// GetContext is a context-aware version of Get.
+func (db *Database) GetContext(ctx context.Context, key string) ([]byte, error) {
 	var data []byte
 
 	if db == nil || db.conn == nil {
@@ -212,9 +224,9 @@ func (db *Database) Get(key string) ([]byte, error) {
 		return data, err
 	}
 
-	err := db.conn.Query(`SELECT data FROM hord WHERE key = ?;`, key).Scan(&data)
+	err := db.conn.Query(`SELECT data FROM hord WHERE key = ?;`, key).WithContext(ctx).Scan(&data)
 	if err != nil && err != gocql.ErrNotFound {
-		return data, err
+		return data, mapError(err)
 	}
 	if err == gocql.ErrNotFound {
 		return data, hord.ErrNil
@@ -226,6 +238,58 @@ func (db *Database) Get(key string) ([]byte, error) {
 // Set is called when data within the database needs to be updated or inserted. This function will
 // take the data provided and create an entry within the database using the key as a lookup value.
 func (db *Database) Set(key string, data []byte) error {
+	return db.SetContext(context.Background(), key, data)
+}
+
+// SetContext is a context-aware version of Set.
+func (db *Database) SetContext(ctx context.Context, key string, data []byte) error {
+	if db == nil || db.conn == nil {
+		return hord.ErrNoDial
+	}
+
+	if err := hord.ValidKey(key); err != nil {
+		return err
+	}
+
+	if err := hord.ValidData(data); err != nil {
+		return err
+	}
+
+	err := db.conn.Query(`UPDATE hord SET data = ? WHERE key = ?`, data, key).WithContext(ctx).Exec()
+	return mapError(err)
+}
+
+// Create is called when data needs to be inserted only if the key does not already exist. This function uses a
+// Cassandra lightweight transaction (INSERT ... IF NOT EXISTS), returning hord.ErrKeyExists if the key is already
+// present.
+func (db *Database) Create(key string, data []byte) error {
+	if db == nil || db.conn == nil {
+		return hord.ErrNoDial
+	}
+
+	if err := hord.ValidKey(key); err != nil {
+		return err
+	}
+
+	if err := hord.ValidData(data); err != nil {
+		return err
+	}
+
+	applied, err := db.conn.Query(`INSERT INTO hord (key, data) VALUES (?, ?) IF NOT EXISTS`, key, data).MapScanCAS(map[string]interface{}{})
+	if err != nil {
+		return mapError(err)
+	}
+	if !applied {
+		return hord.ErrKeyExists
+	}
+
+	return nil
+}
+
+// SetWithTTL is called when data within the database needs to be updated or inserted with an expiration. This
+// function uses the Cassandra USING TTL clause, allowing Cassandra to expire the data once the TTL elapses.
+// Cassandra TTLs have a precision of seconds, TTLs are rounded up to the nearest second.
+func (db *Database) SetWithTTL(key string, data []byte, ttl time.Duration) error {
 	if db == nil || db.conn == nil {
 		return hord.ErrNoDial
 	}
@@ -238,13 +302,125 @@ func (db *Database) Set(key string, data []byte) error {
 		return err
 	}
 
-	err := db.conn.Query(`UPDATE hord SET data = ? WHERE key = ?`, data, key).Exec()
-	return err
+	if err := hord.ValidTTL(ttl); err != nil {
+		return err
+	}
+
+	seconds := int((ttl + time.Second - 1) / time.Second)
+	err := db.conn.Query(`UPDATE hord USING TTL ? SET data = ? WHERE key = ?`, seconds, data, key).Exec()
+	return mapError(err)
 }
 
 // Delete is called when data within the database needs to be deleted. This function will delete
 // the data stored within the database for the specified key.
 func (db *Database) Delete(key string) error {
+	return db.DeleteContext(context.Background(), key)
+}
+
+// DeleteContext is a context-aware version of Delete.
+func (db *Database) DeleteContext(ctx context.Context, key string) error {
+	if db == nil || db.conn == nil {
+		return hord.ErrNoDial
+	}
+
+	if err := hord.ValidKey(key); err != nil {
+		return err
+	}
+
+	err := db.conn.Query(`DELETE FROM hord WHERE key = ?;`, key).WithContext(ctx).Exec()
+	if err != nil {
+		return mapError(err)
+	}
+
+	return nil
+}
+
+// GetMany is called to retrieve data for multiple keys using a single Cassandra IN query. Keys that do not exist are
+// omitted from the results.
+func (db *Database) GetMany(keys []string) (map[string][]byte, error) {
+	if db == nil || db.conn == nil {
+		return nil, hord.ErrNoDial
+	}
+
+	if err := hord.ValidKeys(keys); err != nil {
+		return nil, err
+	}
+
+	results := make(map[string][]byte, len(keys))
+	if len(keys) == 0 {
+		return results, nil
+	}
+
+	var key string
+	var data []byte
+	l := db.conn.Query(`SELECT key, data FROM hord WHERE key IN ?;`, keys).Iter()
+	for l.Scan(&key, &data) {
+		results[key] = data
+		data = nil
+	}
+
+	err := l.Close()
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	return results, nil
+}
+
+// SetMany is called to insert or update multiple keys using a single Cassandra unlogged batch. Unlogged batches
+// reduce round trips but do not guarantee atomicity across partitions.
+func (db *Database) SetMany(items map[string][]byte) error {
+	if db == nil || db.conn == nil {
+		return hord.ErrNoDial
+	}
+
+	if err := hord.ValidItems(items); err != nil {
+		return err
+	}
+
+	if len(items) == 0 {
+		return nil
+	}
+
+	b := db.conn.NewBatch(gocql.UnloggedBatch)
+	for k, v := range items {
+		b.Query(`UPDATE hord SET data = ? WHERE key = ?`, v, k)
+	}
+
+	return mapError(db.conn.ExecuteBatch(b))
+}
+
+// DeleteMany is called to delete multiple keys using a single Cassandra IN query.
+func (db *Database) DeleteMany(keys []string) error {
+	if db == nil || db.conn == nil {
+		return hord.ErrNoDial
+	}
+
+	if err := hord.ValidKeys(keys); err != nil {
+		return err
+	}
+
+	if len(keys) == 0 {
+		return nil
+	}
+
+	return mapError(db.conn.Query(`DELETE FROM hord WHERE key IN ?;`, keys).Exec())
+}
+
+// GetWithVersion is called to retrieve data from the database along with the key's current Version. Cassandra
+// lightweight transactions can only compare column values, so the Version is the stored data itself.
+func (db *Database) GetWithVersion(key string) ([]byte, hord.Version, error) {
+	data, err := db.Get(key)
+	if err != nil {
+		return data, nil, err
+	}
+	return data, hord.Version(data), nil
+}
+
+// SetIfVersion is called to update data within the database only if the key's current Version matches the provided
+// Version. This function uses a Cassandra lightweight transaction; if the key has been modified or deleted,
+// hord.ErrConflict is returned.
+func (db *Database) SetIfVersion(key string, data []byte, version hord.Version) error {
 	if db == nil || db.conn == nil {
 		return hord.ErrNoDial
 	}
@@ -253,17 +429,60 @@ func (db *Database) Delete(key string) error {
 		return err
 	}
 
-	err := db.conn.Query(`DELETE FROM hord WHERE key = ?;`, key).Exec()
+	if err := hord.ValidData(data); err != nil {
+		return err
+	}
+
+	if err := hord.ValidVersion(version); err != nil {
+		return err
+	}
+
+	applied, err := db.conn.Query(`UPDATE hord SET data = ? WHERE key = ? IF data = ?`, data, key, []byte(version)).MapScanCAS(map[string]interface{}{})
 	if err != nil {
+		return mapError(err)
+	}
+	if !applied {
+		return hord.ErrConflict
+	}
+
+	return nil
+}
+
+// DeleteIfVersion is called to delete data within the database only if the key's current Version matches the
+// provided Version. This function uses a Cassandra lightweight transaction; if the key has been modified or deleted,
+// hord.ErrConflict is returned.
+func (db *Database) DeleteIfVersion(key string, version hord.Version) error {
+	if db == nil || db.conn == nil {
+		return hord.ErrNoDial
+	}
+
+	if err := hord.ValidKey(key); err != nil {
 		return err
 	}
 
+	if err := hord.ValidVersion(version); err != nil {
+		return err
+	}
+
+	applied, err := db.conn.Query(`DELETE FROM hord WHERE key = ? IF data = ?`, key, []byte(version)).MapScanCAS(map[string]interface{}{})
+	if err != nil {
+		return mapError(err)
+	}
+	if !applied {
+		return hord.ErrConflict
+	}
+
 	return nil
 }
 
 // Keys is called to retrieve a list of keys stored within the database. This function will query
 // the Cassandra cluster returning all keys used within the hord database.
 func (db *Database) Keys() ([]string, error) {
+	return db.KeysContext(context.Background())
+}
+
+// KeysContext is a context-aware version of Keys.
+func (db *Database) KeysContext(ctx context.Context) ([]string, error) {
 	var keys []string
 	var key string
 
@@ -271,29 +490,48 @@ func (db *Database) Keys() ([]string, error) {
 		return keys, hord.ErrNoDial
 	}
 
-	l := db.conn.Query("SELECT key from hord;").Iter()
+	l := db.conn.Query("SELECT key from hord;").WithContext(ctx).Iter()
 	for l.Scan(&key) {
 		keys = append(keys, key)
 	}
 
 	err := l.Close()
 	if err != nil {
-		return keys, err
+		return keys, mapError(err)
 	}
 
 	return keys, nil
 }
 
+// Capabilities returns the optional features supported by the Cassandra driver. Cassandra distributes keys by hash
+// and has no change feed available through CQL, so prefix, range, and watch operations are not supported.
+func (db *Database) Capabilities() hord.Capabilities {
+	return hord.Capabilities{
+		Context:        true,
+		TTL:            true,
+		Batch:          true,
+		Iterate:        true,
+		CompareAndSwap: true,
+		Create:         true,
+		Txn:            true,
+	}
+}
+
 // HealthCheck is used to verify connectivity and health of the Cassandra cluster. This function
 // simply runs a generic query against Cassandra. If the query errors in any fashion this function
 // will also return an error.
 func (db *Database) HealthCheck() error {
+	return db.HealthCheckContext(context.Background())
+}
+
+// HealthCheckContext is a context-aware version of HealthCheck.
+func (db *Database) HealthCheckContext(ctx context.Context) error {
 	if db == nil || db.conn == nil {
 		return hord.ErrNoDial
 	}
-	err := db.conn.Query("SELECT now() FROM system.local;").Exec()
+	err := db.conn.Query("SELECT now() FROM system.local;").WithContext(ctx).Exec()
 	if err != nil {
-		return fmt.Errorf("health check of Cassandra cluster failed")
+		return fmt.Errorf("health check of Cassandra cluster failed - %w", mapError(err))
 	}
 	return nil
 }
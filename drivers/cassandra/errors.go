@@ -0,0 +1,40 @@
+package cassandra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gocql/gocql"
+	"github.com/madflojo/hord"
+)
+
+// mapError maps errors returned by gocql onto the common Hord errors, keeping the original error within the chain.
+// Errors without a Hord equivalent are returned unchanged.
+func mapError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var reqErr gocql.RequestError
+	if errors.As(err, &reqErr) {
+		switch reqErr.Code() {
+		case gocql.ErrCodeWriteTimeout, gocql.ErrCodeReadTimeout, gocql.ErrCodeCASWriteUnknown:
+			return fmt.Errorf("%w: %w", hord.ErrTimeout, err)
+		case gocql.ErrCodeUnavailable, gocql.ErrCodeOverloaded, gocql.ErrCodeBootstrapping:
+			return fmt.Errorf("%w: %w", hord.ErrUnavailable, err)
+		}
+		return err
+	}
+
+	switch {
+	case errors.Is(err, gocql.ErrTimeoutNoResponse), errors.Is(err, context.DeadlineExceeded):
+		return fmt.Errorf("%w: %w", hord.ErrTimeout, err)
+	case errors.Is(err, gocql.ErrSessionClosed):
+		return fmt.Errorf("%w: %w", hord.ErrClosed, err)
+	case errors.Is(err, gocql.ErrNoConnections), errors.Is(err, gocql.ErrConnectionClosed),
+		errors.Is(err, gocql.ErrNoStreams), errors.Is(err, gocql.ErrUnavailable):
+		return fmt.Errorf("%w: %w", hord.ErrUnavailable, err)
+	}
+	return err
+}
@@ -0,0 +1,66 @@
+package cassandra
+
+import (
+	"github.com/gocql/gocql"
+	"github.com/madflojo/hord"
+)
+
+// pageSize is the number of keys fetched from Cassandra with each page.
+const pageSize = 1000
+
+// Iterator walks the keys stored within Cassandra using driver-level paging. Only a single page of keys is held in
+// memory at a time, with subsequent pages fetched as the iterator advances.
+type Iterator struct {
+	// iter is the underlying paged Cassandra iterator
+	iter *gocql.Iter
+
+	// key is the current key
+	key string
+
+	// err holds any error encountered while iterating
+	err error
+}
+
+// IterateKeys returns an Iterator that walks all keys stored within Cassandra, fetching keys one page at a time.
+func (db *Database) IterateKeys() (hord.Iterator, error) {
+	if db == nil || db.conn == nil {
+		return nil, hord.ErrNoDial
+	}
+	return &Iterator{iter: db.conn.Query("SELECT key from hord;").PageSize(pageSize).Iter()}, nil
+}
+
+// Next advances the iterator, fetching the next page of keys from Cassandra when needed.
+func (i *Iterator) Next() bool {
+	if i.iter == nil {
+		return false
+	}
+
+	if i.iter.Scan(&i.key) {
+		return true
+	}
+
+	// Scan returns false at the end of results or on error, Close reports which
+	i.err = mapError(i.iter.Close())
+	i.iter = nil
+	return false
+}
+
+// Key returns the current key.
+func (i *Iterator) Key() string {
+	return i.key
+}
+
+// Err returns any error encountered while iterating.
+func (i *Iterator) Err() error {
+	return i.err
+}
+
+// Close releases the underlying Cassandra iterator.
+func (i *Iterator) Close() error {
+	if i.iter == nil {
+		return nil
+	}
+	err := i.iter.Close()
+	i.iter = nil
+	return mapError(err)
+}
@@ -0,0 +1,83 @@
+package cassandra
+
+import (
+	"github.com/gocql/gocql"
+	"github.com/madflojo/hord"
+)
+
+// Txn executes fn and applies the writes made through tx using a Cassandra logged batch. Logged batches guarantee
+// that either all or none of the writes are eventually applied, even across partitions. Reads within fn are not
+// isolated; they return data written earlier in the same transaction, otherwise the currently stored data.
+func (db *Database) Txn(fn func(tx hord.Tx) error) error {
+	if db == nil || db.conn == nil {
+		return hord.ErrNoDial
+	}
+
+	tx := &txn{db: db, writes: make(map[string][]byte)}
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	if len(tx.writes) == 0 {
+		return nil
+	}
+
+	b := db.conn.NewBatch(gocql.LoggedBatch)
+	for k, v := range tx.writes {
+		if v == nil {
+			b.Query(`DELETE FROM hord WHERE key = ?;`, k)
+			continue
+		}
+		b.Query(`UPDATE hord SET data = ? WHERE key = ?`, v, k)
+	}
+
+	return mapError(db.conn.ExecuteBatch(b))
+}
+
+// txn is the Cassandra implementation of hord.Tx.
+type txn struct {
+	db *Database
+
+	// writes holds batched writes, a nil value marks the key as deleted
+	writes map[string][]byte
+}
+
+// Get retrieves data within the transaction, returning batched writes before stored data.
+func (tx *txn) Get(key string) ([]byte, error) {
+	if err := hord.ValidKey(key); err != nil {
+		return nil, err
+	}
+
+	if v, ok := tx.writes[key]; ok {
+		if v == nil {
+			return nil, hord.ErrNil
+		}
+		return v, nil
+	}
+
+	return tx.db.Get(key)
+}
+
+// Set adds the write to the batch executed when the transaction commits.
+func (tx *txn) Set(key string, data []byte) error {
+	if err := hord.ValidKey(key); err != nil {
+		return err
+	}
+
+	if err := hord.ValidData(data); err != nil {
+		return err
+	}
+
+	tx.writes[key] = data
+	return nil
+}
+
+// Delete adds the delete to the batch executed when the transaction commits.
+func (tx *txn) Delete(key string) error {
+	if err := hord.ValidKey(key); err != nil {
+		return err
+	}
+
+	tx.writes[key] = nil
+	return nil
+}
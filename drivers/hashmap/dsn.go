@@ -0,0 +1,68 @@
+package hashmap
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/madflojo/hord"
+)
+
+func init() {
+	hord.Register("hashmap", open)
+}
+
+// open is the hord.Factory for the hashmap driver.
+func open(dsn string) (hord.Database, error) {
+	cfg, err := ParseDSN(dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := Dial(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return db, nil
+}
+
+// ParseDSN parses a hashmap URL into a Config. The remainder of the URL after the scheme is an optional YAML or JSON
+// file path used to persist data; use three slashes for absolute paths.
+//
+//	hashmap://
+//	hashmap:///var/lib/app/data.json?sweep_interval=30s
+//
+// Supported query parameters are sweep_interval.
+func ParseDSN(dsn string) (Config, error) {
+	var cfg Config
+
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return cfg, fmt.Errorf("unable to parse DSN - %w", err)
+	}
+
+	if u.Scheme != "hashmap" {
+		return cfg, fmt.Errorf("unsupported DSN scheme %q", u.Scheme)
+	}
+
+	cfg.Filename = u.Opaque
+	if cfg.Filename == "" {
+		cfg.Filename = u.Host + u.Path
+	}
+
+	q := u.Query()
+	for k := range q {
+		v := q.Get(k)
+		switch k {
+		case "sweep_interval":
+			cfg.SweepInterval, err = time.ParseDuration(v)
+		default:
+			return cfg, fmt.Errorf("unknown DSN parameter %q", k)
+		}
+		if err != nil {
+			return cfg, fmt.Errorf("invalid DSN parameter %q - %w", k, err)
+		}
+	}
+
+	return cfg, nil
+}
@@ -57,13 +57,19 @@ Hord provides a simple abstraction for working with the hashmap driver, with eas
 package hashmap
 
 import (
+	"context"
+	"encoding/binary"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/madflojo/hord"
 	"gopkg.in/yaml.v3"
@@ -71,8 +77,13 @@ import (
 
 // Config represents the configuration for the hashmap database.
 type Config struct {
-	// Filename is an optional parameter that accepts the path to a YAML or JSON file to read/write data
+	// Filename is an optional parameter that accepts the path to a YAML or JSON file to read/write data. Expirations
+	// of keys set with a TTL are stored alongside it in a JSON file with an additional .expires extension.
 	Filename string
+
+	// SweepInterval defines how often keys with an expired TTL are removed from memory. Expired keys are never
+	// returned regardless of this setting. Default is 1 minute.
+	SweepInterval time.Duration
 }
 
 // Database is an in-memory hashmap implementation of the hord.Database interface.
@@ -83,6 +94,27 @@ type Database struct {
 
 	// data is used to store data in a simple map
 	data map[string]ByteSlice
+
+	// index holds a sorted list of keys, used for prefix and range queries
+	index []string
+
+	// expires tracks the expiration time of keys set with a TTL
+	expires map[string]time.Time
+
+	// revision is incremented on every write and used to version keys for compare-and-swap operations
+	revision uint64
+
+	// revisions holds the revision of the last write to each key
+	revisions map[string]uint64
+
+	// watchers notifies callers of Watch when keys change
+	watchers hord.Broadcaster
+
+	// sweeper is used to start the expired key sweeper the first time a TTL is set
+	sweeper sync.Once
+
+	// stop is closed to stop the expired key sweeper
+	stop chan struct{}
 }
 
 // Dial initializes and returns a new hashmap database instance.
@@ -95,13 +127,29 @@ func Dial(conf Config) (*Database, error) {
 		}
 	}
 
+	if conf.SweepInterval <= 0 {
+		conf.SweepInterval = time.Minute
+	}
+
 	db := &Database{config: conf}
 	db.data = make(map[string]ByteSlice)
+	db.expires = make(map[string]time.Time)
+	db.revisions = make(map[string]uint64)
+	db.stop = make(chan struct{})
 	return db, nil
 }
 
 // Setup sets up the hashmap database. If file storage is enabled, this will load from the file or create it if it does not exist.
 func (db *Database) Setup() error {
+	return db.SetupContext(context.Background())
+}
+
+// SetupContext is a context-aware version of Setup.
+func (db *Database) SetupContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	if db.config.Filename == "" {
 		return nil
 	}
@@ -109,6 +157,18 @@ func (db *Database) Setup() error {
 	db.Lock()
 	defer db.Unlock()
 
+	// Close clears in-memory state, stops the sweeper, and closes watchers, re-create them when the database is
+	// re-opened
+	if db.data == nil {
+		db.data = make(map[string]ByteSlice)
+		db.stop = make(chan struct{})
+		db.sweeper = sync.Once{}
+		db.watchers = hord.Broadcaster{}
+	}
+	if db.expires == nil {
+		db.expires = make(map[string]time.Time)
+	}
+
 	// check file and create if it does not exist
 	file, err := os.OpenFile(db.config.Filename, os.O_RDONLY|os.O_CREATE, 0640)
 	if err != nil {
@@ -134,16 +194,26 @@ func (db *Database) Setup() error {
 		return fmt.Errorf("unable to unmarshal data from file: %w", err)
 	}
 
-	return nil
+	db.reindex()
+	return db.loadExpires()
 }
 
 // Get retrieves data from the hashmap database based on the provided key.
 // It returns the data associated with the key or an error if the key is invalid or the data does not exist.
 func (db *Database) Get(key string) ([]byte, error) {
+	return db.GetContext(context.Background(), key)
+}
+
+// GetContext is a context-aware version of Get.
+func (db *Database) GetContext(ctx context.Context, key string) ([]byte, error) {
 	if err := hord.ValidKey(key); err != nil {
 		return []byte(""), err
 	}
 
+	if err := ctx.Err(); err != nil {
+		return []byte(""), err
+	}
+
 	db.RLock()
 	defer db.RUnlock()
 	if db.data == nil {
@@ -151,7 +221,7 @@ func (db *Database) Get(key string) ([]byte, error) {
 	}
 
 	v, ok := db.data[key]
-	if ok {
+	if ok && !db.expired(key, time.Now()) {
 		return v, nil
 	}
 	return []byte(""), hord.ErrNil
@@ -160,6 +230,11 @@ func (db *Database) Get(key string) ([]byte, error) {
 // Set inserts or updates data in the hashmap database based on the provided key.
 // It returns an error if the key or data is invalid.
 func (db *Database) Set(key string, data []byte) error {
+	return db.SetContext(context.Background(), key, data)
+}
+
+// SetContext is a context-aware version of Set.
+func (db *Database) SetContext(ctx context.Context, key string, data []byte) error {
 	if err := hord.ValidKey(key); err != nil {
 		return err
 	}
@@ -168,51 +243,368 @@ func (db *Database) Set(key string, data []byte) error {
 		return err
 	}
 
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	db.Lock()
 	defer db.Unlock()
 	if db.data == nil {
 		return hord.ErrNoDial
 	}
 
-	db.data[key] = data
+	db.put(key, data)
+	delete(db.expires, key)
+	return db.saveToLocalFile()
+}
+
+// Create inserts data into the hashmap database only if the provided key does not exist.
+// It returns hord.ErrKeyExists if the key is already present.
+func (db *Database) Create(key string, data []byte) error {
+	if err := hord.ValidKey(key); err != nil {
+		return err
+	}
+
+	if err := hord.ValidData(data); err != nil {
+		return err
+	}
+
+	db.Lock()
+	defer db.Unlock()
+	if db.data == nil {
+		return hord.ErrNoDial
+	}
+
+	if _, ok := db.data[key]; ok && !db.expired(key, time.Now()) {
+		return hord.ErrKeyExists
+	}
+
+	db.put(key, data)
+	delete(db.expires, key)
+	return db.saveToLocalFile()
+}
+
+// SetWithTTL inserts or updates data in the hashmap database with an expiration. Once the TTL elapses, the key is
+// no longer returned and will be removed from memory by a background sweeper.
+// When a Filename is configured, expirations are persisted with the data and keys still expire after a reload.
+func (db *Database) SetWithTTL(key string, data []byte, ttl time.Duration) error {
+	if err := hord.ValidKey(key); err != nil {
+		return err
+	}
+
+	if err := hord.ValidData(data); err != nil {
+		return err
+	}
+
+	if err := hord.ValidTTL(ttl); err != nil {
+		return err
+	}
+
+	db.Lock()
+	defer db.Unlock()
+	if db.data == nil {
+		return hord.ErrNoDial
+	}
+
+	db.sweeper.Do(func() {
+		go db.sweep(db.stop)
+	})
+
+	db.put(key, data)
+	db.expires[key] = time.Now().Add(ttl)
 	return db.saveToLocalFile()
 }
 
 // Delete removes data from the hashmap database based on the provided key.
 // It returns an error if the key is invalid.
 func (db *Database) Delete(key string) error {
+	return db.DeleteContext(context.Background(), key)
+}
+
+// DeleteContext is a context-aware version of Delete.
+func (db *Database) DeleteContext(ctx context.Context, key string) error {
 	if err := hord.ValidKey(key); err != nil {
 		return err
 	}
 
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	db.Lock()
 	defer db.Unlock()
 	if db.data == nil {
 		return hord.ErrNoDial
 	}
 
-	delete(db.data, key)
+	db.remove(key)
+	return db.saveToLocalFile()
+}
+
+// GetMany retrieves data for multiple keys from the hashmap database, acquiring the lock once for all keys.
+// Keys that do not exist are omitted from the results.
+func (db *Database) GetMany(keys []string) (map[string][]byte, error) {
+	if err := hord.ValidKeys(keys); err != nil {
+		return nil, err
+	}
+
+	db.RLock()
+	defer db.RUnlock()
+	if db.data == nil {
+		return nil, hord.ErrNoDial
+	}
+
+	now := time.Now()
+	results := make(map[string][]byte, len(keys))
+	for _, k := range keys {
+		v, ok := db.data[k]
+		if ok && !db.expired(k, now) {
+			results[k] = v
+		}
+	}
+	return results, nil
+}
+
+// SetMany inserts or updates multiple keys in the hashmap database, acquiring the lock and writing the file once
+// for all keys.
+func (db *Database) SetMany(items map[string][]byte) error {
+	if err := hord.ValidItems(items); err != nil {
+		return err
+	}
+
+	db.Lock()
+	defer db.Unlock()
+	if db.data == nil {
+		return hord.ErrNoDial
+	}
+
+	for k, v := range items {
+		db.put(k, v)
+		delete(db.expires, k)
+	}
+	return db.saveToLocalFile()
+}
+
+// DeleteMany removes multiple keys from the hashmap database, acquiring the lock and writing the file once for
+// all keys.
+func (db *Database) DeleteMany(keys []string) error {
+	if err := hord.ValidKeys(keys); err != nil {
+		return err
+	}
+
+	db.Lock()
+	defer db.Unlock()
+	if db.data == nil {
+		return hord.ErrNoDial
+	}
+
+	for _, k := range keys {
+		db.remove(k)
+	}
+	return db.saveToLocalFile()
+}
+
+// GetWithVersion retrieves data from the hashmap database along with the key's current Version.
+func (db *Database) GetWithVersion(key string) ([]byte, hord.Version, error) {
+	if err := hord.ValidKey(key); err != nil {
+		return []byte(""), nil, err
+	}
+
+	db.RLock()
+	defer db.RUnlock()
+	if db.data == nil {
+		return []byte(""), nil, hord.ErrNoDial
+	}
+
+	v, ok := db.data[key]
+	if ok && !db.expired(key, time.Now()) {
+		return v, db.version(key), nil
+	}
+	return []byte(""), nil, hord.ErrNil
+}
+
+// SetIfVersion updates data in the hashmap database only if the key's current Version matches the provided Version.
+// It returns hord.ErrConflict if the key has been modified or deleted.
+func (db *Database) SetIfVersion(key string, data []byte, version hord.Version) error {
+	if err := hord.ValidKey(key); err != nil {
+		return err
+	}
+
+	if err := hord.ValidData(data); err != nil {
+		return err
+	}
+
+	if err := hord.ValidVersion(version); err != nil {
+		return err
+	}
+
+	db.Lock()
+	defer db.Unlock()
+	if db.data == nil {
+		return hord.ErrNoDial
+	}
+
+	if !db.matches(key, version) {
+		return hord.ErrConflict
+	}
+
+	db.put(key, data)
+	delete(db.expires, key)
+	return db.saveToLocalFile()
+}
+
+// DeleteIfVersion removes data from the hashmap database only if the key's current Version matches the provided
+// Version. It returns hord.ErrConflict if the key has been modified or deleted.
+func (db *Database) DeleteIfVersion(key string, version hord.Version) error {
+	if err := hord.ValidKey(key); err != nil {
+		return err
+	}
+
+	if err := hord.ValidVersion(version); err != nil {
+		return err
+	}
+
+	db.Lock()
+	defer db.Unlock()
+	if db.data == nil {
+		return hord.ErrNoDial
+	}
+
+	if !db.matches(key, version) {
+		return hord.ErrConflict
+	}
+
+	db.remove(key)
 	return db.saveToLocalFile()
 }
 
 // Keys retrieves a list of keys stored in the hashmap database.
 func (db *Database) Keys() ([]string, error) {
+	return db.KeysContext(context.Background())
+}
+
+// KeysContext is a context-aware version of Keys.
+func (db *Database) KeysContext(ctx context.Context) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return []string{}, err
+	}
+
 	db.RLock()
 	defer db.RUnlock()
 	if db.data == nil {
 		return []string{}, hord.ErrNoDial
 	}
 
+	now := time.Now()
 	var keys []string
-	for k := range db.data {
+	for _, k := range db.index {
+		if db.expired(k, now) {
+			continue
+		}
 		keys = append(keys, k)
 	}
 	return keys, nil
 }
 
+// KeysWithPrefix retrieves a sorted list of keys that start with the provided prefix, using the sorted key index
+// to avoid scanning the entire hashmap.
+func (db *Database) KeysWithPrefix(prefix string) ([]string, error) {
+	db.RLock()
+	defer db.RUnlock()
+	if db.data == nil {
+		return []string{}, hord.ErrNoDial
+	}
+
+	now := time.Now()
+	var keys []string
+	for i := sort.SearchStrings(db.index, prefix); i < len(db.index); i++ {
+		k := db.index[i]
+		if !strings.HasPrefix(k, prefix) {
+			break
+		}
+		if db.expired(k, now) {
+			continue
+		}
+		keys = append(keys, k)
+	}
+	return keys, nil
+}
+
+// Range retrieves the keys and data for all keys greater than or equal to start and less than end, sorted by key.
+// An empty end will return all keys from start onward.
+func (db *Database) Range(start, end string) ([]hord.KeyValue, error) {
+	db.RLock()
+	defer db.RUnlock()
+	if db.data == nil {
+		return nil, hord.ErrNoDial
+	}
+
+	now := time.Now()
+	var results []hord.KeyValue
+	for i := sort.SearchStrings(db.index, start); i < len(db.index); i++ {
+		k := db.index[i]
+		if end != "" && k >= end {
+			break
+		}
+		if db.expired(k, now) {
+			continue
+		}
+		results = append(results, hord.KeyValue{Key: k, Value: db.data[k]})
+	}
+	return results, nil
+}
+
+// IterateKeys returns an Iterator over a snapshot of the keys stored in the hashmap database.
+func (db *Database) IterateKeys() (hord.Iterator, error) {
+	keys, err := db.Keys()
+	if err != nil {
+		return nil, err
+	}
+	return hord.NewSliceIterator(keys), nil
+}
+
+// Watch returns a channel of events for changes to the provided key and any keys that start with it. Events are
+// published in-process for writes made through this Database instance; changes made to the backing file by other
+// processes are not observed. The channel is closed when stop is called or the database is closed.
+func (db *Database) Watch(keyOrPrefix string) (<-chan hord.Event, func()) {
+	db.RLock()
+	defer db.RUnlock()
+	if db.data == nil {
+		ch := make(chan hord.Event)
+		close(ch)
+		return ch, func() {}
+	}
+	return db.watchers.Watch(keyOrPrefix)
+}
+
+// Capabilities returns the optional features supported by the hashmap driver. Every optional feature is supported.
+func (db *Database) Capabilities() hord.Capabilities {
+	return hord.Capabilities{
+		Context:        true,
+		TTL:            true,
+		Batch:          true,
+		Iterate:        true,
+		Prefix:         true,
+		Range:          true,
+		CompareAndSwap: true,
+		Create:         true,
+		Watch:          true,
+		Txn:            true,
+	}
+}
+
 // HealthCheck performs a health check on the hashmap database.
 // Since the hashmap database is an in-memory implementation, it always returns nil.
 func (db *Database) HealthCheck() error {
+	return db.HealthCheckContext(context.Background())
+}
+
+// HealthCheckContext is a context-aware version of HealthCheck.
+func (db *Database) HealthCheckContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	db.RLock()
 	defer db.RUnlock()
 	if db.data == nil {
@@ -229,11 +621,118 @@ func (db *Database) HealthCheck() error {
 	return nil
 }
 
-// Close closes the hashmap database connection and clears all stored data from memory (file remains if used).
+// Close closes the hashmap database connection and clears all stored data from memory (file remains if used). Key
+// versions are kept so that versions read before Close remain valid once the database is re-opened.
 func (db *Database) Close() {
 	db.Lock()
 	defer db.Unlock()
+	if db.data != nil {
+		close(db.stop)
+	}
 	db.data = nil
+	db.index = nil
+	db.expires = nil
+	db.watchers.Close()
+}
+
+// put stores the data, adds new keys to the sorted index, and notifies watchers. It should only be used after
+// acquiring a write lock.
+func (db *Database) put(key string, data []byte) {
+	if _, ok := db.data[key]; !ok {
+		i := sort.SearchStrings(db.index, key)
+		db.index = append(db.index, "")
+		copy(db.index[i+1:], db.index[i:])
+		db.index[i] = key
+	}
+	db.data[key] = data
+	db.revision++
+	db.revisions[key] = db.revision
+	db.watchers.Publish(hord.Event{Type: hord.EventPut, Key: key, Value: data})
+}
+
+// remove deletes the key, its expiration, and its index entry, and notifies watchers. It should only be used after
+// acquiring a write lock.
+func (db *Database) remove(key string) {
+	if _, ok := db.data[key]; ok {
+		i := sort.SearchStrings(db.index, key)
+		if i < len(db.index) && db.index[i] == key {
+			db.index = append(db.index[:i], db.index[i+1:]...)
+		}
+		db.watchers.Publish(hord.Event{Type: hord.EventDelete, Key: key})
+	}
+	delete(db.data, key)
+	delete(db.expires, key)
+	delete(db.revisions, key)
+}
+
+// reindex rebuilds the sorted key index from the stored data and assigns a revision to keys that do not have one.
+// Revisions of keys no longer stored are dropped. It should only be used after acquiring a write lock.
+func (db *Database) reindex() {
+	db.index = make([]string, 0, len(db.data))
+	for k := range db.data {
+		db.index = append(db.index, k)
+		if _, ok := db.revisions[k]; !ok {
+			db.revision++
+			db.revisions[k] = db.revision
+		}
+	}
+	sort.Strings(db.index)
+
+	for k := range db.revisions {
+		if _, ok := db.data[k]; !ok {
+			delete(db.revisions, k)
+		}
+	}
+}
+
+// version returns the encoded revision of the key. It should only be used after acquiring a lock.
+func (db *Database) version(key string) hord.Version {
+	v := make(hord.Version, 8)
+	binary.BigEndian.PutUint64(v, db.revisions[key])
+	return v
+}
+
+// matches returns true if the key exists, has not expired, and its revision matches the provided Version. It should
+// only be used after acquiring a lock.
+func (db *Database) matches(key string, version hord.Version) bool {
+	if _, ok := db.data[key]; !ok || db.expired(key, time.Now()) {
+		return false
+	}
+	return len(version) == 8 && binary.BigEndian.Uint64(version) == db.revisions[key]
+}
+
+// expired returns true if the key has a TTL that has elapsed. It should only be used after acquiring a lock.
+func (db *Database) expired(key string, now time.Time) bool {
+	exp, ok := db.expires[key]
+	return ok && !now.Before(exp)
+}
+
+// sweep periodically removes expired keys from memory until the database is closed.
+func (db *Database) sweep(stop chan struct{}) {
+	ticker := time.NewTicker(db.config.SweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			db.Lock()
+			now := time.Now()
+			removed := 0
+			for k := range db.expires {
+				if db.expired(k, now) {
+					db.remove(k)
+					removed++
+				}
+			}
+			if removed > 0 {
+				// Errors writing the file will resurface on the next write
+				_ = db.saveToLocalFile()
+			}
+			db.Unlock()
+		}
+	}
 }
 
 // saveToLocalFile is a helper function for methods that change the data (Set, Delete) and should
@@ -260,5 +759,66 @@ func (db *Database) saveToLocalFile() error {
 		return fmt.Errorf("error writing data to file %q: %w", db.config.Filename, err)
 	}
 
+	return db.saveExpires()
+}
+
+// expiresFilename returns the path of the file used to persist expirations alongside the data file.
+func (db *Database) expiresFilename() string {
+	return db.config.Filename + ".expires"
+}
+
+// saveExpires writes the expirations of keys set with a TTL to the expirations file, removing the file when no keys
+// have a TTL. It should only be used after acquiring a write lock.
+func (db *Database) saveExpires() error {
+	filename := db.expiresFilename()
+	if len(db.expires) == 0 {
+		err := os.Remove(filename)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("error removing expirations file %q: %w", filename, err)
+		}
+		return nil
+	}
+
+	content, err := json.Marshal(db.expires)
+	if err != nil {
+		return fmt.Errorf("error marshalling expirations: %w", err)
+	}
+
+	err = os.WriteFile(filename, content, 0640)
+	if err != nil {
+		return fmt.Errorf("error writing expirations to file %q: %w", filename, err)
+	}
+
+	return nil
+}
+
+// loadExpires reads the expirations of stored keys from the expirations file, if it exists, and starts the sweeper
+// when any are found. It should only be used after acquiring a write lock.
+func (db *Database) loadExpires() error {
+	filename := db.expiresFilename()
+	content, err := os.ReadFile(filename)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("unable to read expirations file %q: %w", filename, err)
+	}
+
+	expires := make(map[string]time.Time)
+	if err := json.Unmarshal(content, &expires); err != nil {
+		return fmt.Errorf("unable to unmarshal expirations from file: %w", err)
+	}
+
+	for k, exp := range expires {
+		if _, ok := db.data[k]; ok {
+			db.expires[k] = exp
+		}
+	}
+
+	if len(db.expires) > 0 {
+		db.sweeper.Do(func() {
+			go db.sweep(db.stop)
+		})
+	}
 	return nil
 }
@@ -1,10 +1,15 @@
 package hashmap
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/madflojo/hord"
 	"gopkg.in/yaml.v3"
 )
 
@@ -128,6 +133,29 @@ func TestLoadingFromExistingFile(t *testing.T) {
 			}
 		})
 
+		t.Run("ReopenAfterClose_"+tt.extension, func(t *testing.T) {
+			filename := "testdata/load_data_test." + tt.extension
+
+			db, err := Dial(Config{Filename: filename})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err := db.Setup(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			db.Close()
+
+			if err := db.Setup(); err != nil {
+				t.Fatalf("unexpected error re-opening: %v", err)
+			}
+			defer db.Close()
+
+			value, err := db.Get("key")
+			if err != nil || string(value) != "value" {
+				t.Errorf("unexpected value: %s, error: %v", value, err)
+			}
+		})
+
 		t.Run("InvalidFileContents_"+tt.extension, func(t *testing.T) {
 			filename := "testdata/load_data_invalid_test." + tt.extension
 
@@ -267,3 +295,767 @@ func readFile(filename string, unmarshal func([]byte, interface{}) error) (map[s
 
 	return parsedData, nil
 }
+
+func TestContext(t *testing.T) {
+	db, err := Dial(Config{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer db.Close()
+
+	t.Run("ActiveContext", func(t *testing.T) {
+		ctx := context.Background()
+		err := db.SetContext(ctx, "key", []byte("value"))
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		value, err := db.GetContext(ctx, "key")
+		if err != nil || string(value) != "value" {
+			t.Errorf("unexpected value: %s, error: %v", value, err)
+		}
+	})
+
+	t.Run("CanceledContext", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		if err := db.SetupContext(ctx); !errors.Is(err, context.Canceled) {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if err := db.HealthCheckContext(ctx); !errors.Is(err, context.Canceled) {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if _, err := db.GetContext(ctx, "key"); !errors.Is(err, context.Canceled) {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if err := db.SetContext(ctx, "key", []byte("value")); !errors.Is(err, context.Canceled) {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if err := db.DeleteContext(ctx, "key"); !errors.Is(err, context.Canceled) {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if _, err := db.KeysContext(ctx); !errors.Is(err, context.Canceled) {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestTTL(t *testing.T) {
+	db, err := Dial(Config{SweepInterval: 10 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer db.Close()
+
+	t.Run("InvalidTTL", func(t *testing.T) {
+		err := db.SetWithTTL("key", []byte("value"), 0)
+		if !errors.Is(err, hord.ErrInvalidTTL) {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("KeyExpires", func(t *testing.T) {
+		err := db.SetWithTTL("expiring", []byte("value"), 50*time.Millisecond)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		value, err := db.Get("expiring")
+		if err != nil || string(value) != "value" {
+			t.Fatalf("unexpected value: %s, error: %v", value, err)
+		}
+
+		<-time.After(100 * time.Millisecond)
+
+		_, err = db.Get("expiring")
+		if !errors.Is(err, hord.ErrNil) {
+			t.Errorf("expected ErrNil after TTL elapsed, got: %v", err)
+		}
+
+		keys, err := db.Keys()
+		if err != nil || len(keys) != 0 {
+			t.Errorf("unexpected keys: %v, error: %v", keys, err)
+		}
+
+		db.RLock()
+		_, ok := db.data["expiring"]
+		db.RUnlock()
+		if ok {
+			t.Errorf("expected expired key to be swept from memory")
+		}
+	})
+
+	t.Run("SetClearsTTL", func(t *testing.T) {
+		err := db.SetWithTTL("persist", []byte("value"), 50*time.Millisecond)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		err = db.Set("persist", []byte("value"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		<-time.After(100 * time.Millisecond)
+
+		value, err := db.Get("persist")
+		if err != nil || string(value) != "value" {
+			t.Errorf("unexpected value: %s, error: %v", value, err)
+		}
+	})
+}
+
+func TestBatch(t *testing.T) {
+	filename := "testdata/batch_test.json"
+	defer os.RemoveAll(filename)
+
+	db, err := Dial(Config{Filename: filename})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer db.Close()
+
+	err = db.Setup()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	t.Run("InvalidInput", func(t *testing.T) {
+		if _, err := db.GetMany([]string{""}); !errors.Is(err, hord.ErrInvalidKey) {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if err := db.SetMany(map[string][]byte{"key": nil}); !errors.Is(err, hord.ErrInvalidData) {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if err := db.DeleteMany([]string{""}); !errors.Is(err, hord.ErrInvalidKey) {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("SetGetDelete", func(t *testing.T) {
+		err := db.SetMany(map[string][]byte{"a": []byte("1"), "b": []byte("2")})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		data, err := readFile(filename, json.Unmarshal)
+		if err != nil || len(data) != 2 {
+			t.Errorf("unexpected file contents: %v, error: %v", data, err)
+		}
+
+		results, err := db.GetMany([]string{"a", "b", "missing"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(results) != 2 || string(results["a"]) != "1" || string(results["b"]) != "2" {
+			t.Errorf("unexpected results: %v", results)
+		}
+
+		err = db.DeleteMany([]string{"a", "b"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		keys, err := db.Keys()
+		if err != nil || len(keys) != 0 {
+			t.Errorf("unexpected keys: %v, error: %v", keys, err)
+		}
+	})
+
+	t.Run("Closed", func(t *testing.T) {
+		db.Close()
+		if _, err := db.GetMany([]string{"a"}); !errors.Is(err, hord.ErrNoDial) {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if err := db.SetMany(map[string][]byte{"a": []byte("1")}); !errors.Is(err, hord.ErrNoDial) {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if err := db.DeleteMany([]string{"a"}); !errors.Is(err, hord.ErrNoDial) {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestIterateKeys(t *testing.T) {
+	db, err := Dial(Config{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer db.Close()
+
+	err = db.SetMany(map[string][]byte{"a": []byte("1"), "b": []byte("2")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	iter, err := db.IterateKeys()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer iter.Close()
+
+	count := 0
+	for iter.Next() {
+		count++
+	}
+	if count != 2 {
+		t.Errorf("unexpected number of keys: %d", count)
+	}
+}
+
+func TestPrefixAndRange(t *testing.T) {
+	db, err := Dial(Config{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer db.Close()
+
+	err = db.SetMany(map[string][]byte{
+		"tenant:1:user:1": []byte("1"),
+		"tenant:1:user:2": []byte("2"),
+		"tenant:2:user:1": []byte("3"),
+		"tenant:3:user:1": []byte("4"),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	t.Run("KeysWithPrefix", func(t *testing.T) {
+		keys, err := db.KeysWithPrefix("tenant:1:")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(keys) != 2 || keys[0] != "tenant:1:user:1" || keys[1] != "tenant:1:user:2" {
+			t.Errorf("unexpected keys: %v", keys)
+		}
+	})
+
+	t.Run("KeysWithPrefixNoMatch", func(t *testing.T) {
+		keys, err := db.KeysWithPrefix("missing:")
+		if err != nil || len(keys) != 0 {
+			t.Errorf("unexpected keys: %v, error: %v", keys, err)
+		}
+	})
+
+	t.Run("Range", func(t *testing.T) {
+		results, err := db.Range("tenant:1:user:2", "tenant:3:")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(results) != 2 || results[0].Key != "tenant:1:user:2" || string(results[1].Value) != "3" {
+			t.Errorf("unexpected results: %+v", results)
+		}
+	})
+
+	t.Run("RangeOpenEnded", func(t *testing.T) {
+		results, err := db.Range("tenant:2:", "")
+		if err != nil || len(results) != 2 {
+			t.Errorf("unexpected results: %+v, error: %v", results, err)
+		}
+	})
+
+	t.Run("IndexUpdatedOnDelete", func(t *testing.T) {
+		err := db.Delete("tenant:1:user:1")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		keys, err := db.KeysWithPrefix("tenant:1:")
+		if err != nil || len(keys) != 1 || keys[0] != "tenant:1:user:2" {
+			t.Errorf("unexpected keys: %v, error: %v", keys, err)
+		}
+	})
+
+	t.Run("Closed", func(t *testing.T) {
+		db.Close()
+		if _, err := db.KeysWithPrefix("tenant:"); !errors.Is(err, hord.ErrNoDial) {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if _, err := db.Range("a", "b"); !errors.Is(err, hord.ErrNoDial) {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestVersion(t *testing.T) {
+	db, err := Dial(Config{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer db.Close()
+
+	if err := db.Set("cas", []byte("v1")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data, version, err := db.GetWithVersion("cas")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(data) != "v1" {
+		t.Errorf("unexpected data: %s", data)
+	}
+
+	t.Run("SetIfVersion", func(t *testing.T) {
+		if err := db.SetIfVersion("cas", []byte("v2"), version); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		err := db.SetIfVersion("cas", []byte("v3"), version)
+		if !errors.Is(err, hord.ErrConflict) {
+			t.Errorf("expected ErrConflict using stale version, got %v", err)
+		}
+
+		data, err := db.Get("cas")
+		if err != nil || string(data) != "v2" {
+			t.Errorf("unexpected data: %s, error: %v", data, err)
+		}
+	})
+
+	t.Run("DeleteIfVersion", func(t *testing.T) {
+		_, current, err := db.GetWithVersion("cas")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if err := db.DeleteIfVersion("cas", version); !errors.Is(err, hord.ErrConflict) {
+			t.Errorf("expected ErrConflict using stale version, got %v", err)
+		}
+
+		if err := db.DeleteIfVersion("cas", current); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if _, err := db.Get("cas"); !errors.Is(err, hord.ErrNil) {
+			t.Errorf("expected ErrNil after DeleteIfVersion, got %v", err)
+		}
+	})
+
+	t.Run("Missing Key", func(t *testing.T) {
+		if _, _, err := db.GetWithVersion("cas"); !errors.Is(err, hord.ErrNil) {
+			t.Errorf("expected ErrNil, got %v", err)
+		}
+
+		if err := db.SetIfVersion("cas", []byte("v4"), version); !errors.Is(err, hord.ErrConflict) {
+			t.Errorf("expected ErrConflict for deleted key, got %v", err)
+		}
+	})
+
+	t.Run("Recreated Key", func(t *testing.T) {
+		if err := db.Set("cas", []byte("v1")); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if err := db.SetIfVersion("cas", []byte("v2"), version); !errors.Is(err, hord.ErrConflict) {
+			t.Errorf("expected ErrConflict for recreated key, got %v", err)
+		}
+	})
+
+	t.Run("Invalid Version", func(t *testing.T) {
+		if err := db.SetIfVersion("cas", []byte("v2"), nil); !errors.Is(err, hord.ErrInvalidVersion) {
+			t.Errorf("expected ErrInvalidVersion, got %v", err)
+		}
+	})
+}
+
+func TestCreate(t *testing.T) {
+	db, err := Dial(Config{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer db.Close()
+
+	if err := db.Create("claim", []byte("a")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := db.Create("claim", []byte("b")); !errors.Is(err, hord.ErrKeyExists) {
+		t.Errorf("expected ErrKeyExists, got %v", err)
+	}
+
+	data, err := db.Get("claim")
+	if err != nil || string(data) != "a" {
+		t.Errorf("unexpected data: %s, error: %v", data, err)
+	}
+
+	t.Run("Expired Key", func(t *testing.T) {
+		if err := db.SetWithTTL("expiring", []byte("a"), time.Millisecond); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		<-time.After(5 * time.Millisecond)
+
+		if err := db.Create("expiring", []byte("b")); err != nil {
+			t.Errorf("unexpected error creating expired key: %v", err)
+		}
+	})
+
+	t.Run("Closed", func(t *testing.T) {
+		db.Close()
+		if err := db.Create("closed", []byte("a")); !errors.Is(err, hord.ErrNoDial) {
+			t.Errorf("expected ErrNoDial, got %v", err)
+		}
+	})
+}
+
+func TestWatch(t *testing.T) {
+	db, err := Dial(Config{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer db.Close()
+
+	events, stop := db.Watch("config:")
+	defer stop()
+
+	if err := db.Set("other", []byte("1")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := db.Set("config:a", []byte("2")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := db.Delete("config:a"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := db.Delete("config:missing"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := db.SetMany(map[string][]byte{"config:b": []byte("3")}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, want := range []hord.Event{
+		{Type: hord.EventPut, Key: "config:a", Value: []byte("2")},
+		{Type: hord.EventDelete, Key: "config:a"},
+		{Type: hord.EventPut, Key: "config:b", Value: []byte("3")},
+	} {
+		select {
+		case e := <-events:
+			if e.Type != want.Type || e.Key != want.Key || string(e.Value) != string(want.Value) {
+				t.Errorf("expected event %+v, got %+v", want, e)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for event %+v", want)
+		}
+	}
+
+	t.Run("Closed", func(t *testing.T) {
+		db.Close()
+
+		select {
+		case _, ok := <-events:
+			if ok {
+				t.Errorf("expected channel to be closed")
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for channel to close")
+		}
+
+		events, stop := db.Watch("")
+		defer stop()
+		if _, ok := <-events; ok {
+			t.Errorf("expected closed channel after Close")
+		}
+	})
+}
+
+func TestTxn(t *testing.T) {
+	db, err := Dial(Config{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer db.Close()
+
+	if err := db.Set("stale", []byte("1")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	t.Run("Commit", func(t *testing.T) {
+		err := db.Txn(func(tx hord.Tx) error {
+			if err := tx.Set("record:1", []byte("data")); err != nil {
+				return err
+			}
+			if err := tx.Set("index:1", []byte("record:1")); err != nil {
+				return err
+			}
+			if err := tx.Delete("stale"); err != nil {
+				return err
+			}
+
+			// Staged writes are visible within the transaction
+			if d, err := tx.Get("record:1"); err != nil || string(d) != "data" {
+				t.Errorf("unexpected data within transaction: %s, error: %v", d, err)
+			}
+			if _, err := tx.Get("stale"); !errors.Is(err, hord.ErrNil) {
+				t.Errorf("expected ErrNil for deleted key within transaction, got %v", err)
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		results, err := db.GetMany([]string{"record:1", "index:1", "stale"})
+		if err != nil || len(results) != 2 {
+			t.Errorf("unexpected results: %v, error: %v", results, err)
+		}
+	})
+
+	t.Run("Rollback", func(t *testing.T) {
+		errFail := errors.New("fail")
+		err := db.Txn(func(tx hord.Tx) error {
+			if err := tx.Set("record:2", []byte("data")); err != nil {
+				return err
+			}
+			return errFail
+		})
+		if !errors.Is(err, errFail) {
+			t.Fatalf("expected transaction error, got %v", err)
+		}
+
+		if _, err := db.Get("record:2"); !errors.Is(err, hord.ErrNil) {
+			t.Errorf("expected ErrNil for rolled back key, got %v", err)
+		}
+	})
+
+	t.Run("Invalid Data", func(t *testing.T) {
+		err := db.Txn(func(tx hord.Tx) error {
+			return tx.Set("", []byte("data"))
+		})
+		if !errors.Is(err, hord.ErrInvalidKey) {
+			t.Errorf("expected ErrInvalidKey, got %v", err)
+		}
+	})
+}
+
+func TestCapabilities(t *testing.T) {
+	db, err := Dial(Config{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer db.Close()
+
+	expected := hord.Capabilities{
+		Context:        true,
+		TTL:            true,
+		Batch:          true,
+		Iterate:        true,
+		Prefix:         true,
+		Range:          true,
+		CompareAndSwap: true,
+		Create:         true,
+		Watch:          true,
+		Txn:            true,
+	}
+	if c := hord.CapabilitiesOf(db); c != expected {
+		t.Errorf("unexpected capabilities: %+v", c)
+	}
+}
+
+func TestParseDSN(t *testing.T) {
+	t.Run("In Memory", func(t *testing.T) {
+		cfg, err := ParseDSN("hashmap://?sweep_interval=30s")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if cfg.Filename != "" || cfg.SweepInterval != 30*time.Second {
+			t.Errorf("unexpected config: %+v", cfg)
+		}
+	})
+
+	t.Run("Filename", func(t *testing.T) {
+		for dsn, filename := range map[string]string{
+			"hashmap:///tmp/data.json":   "/tmp/data.json",
+			"hashmap://./data.yaml":      "./data.yaml",
+			"hashmap:testdata/data.yaml": "testdata/data.yaml",
+		} {
+			cfg, err := ParseDSN(dsn)
+			if err != nil {
+				t.Fatalf("unexpected error parsing %s: %v", dsn, err)
+			}
+			if cfg.Filename != filename {
+				t.Errorf("expected filename %s from %s, got %s", filename, dsn, cfg.Filename)
+			}
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		for _, dsn := range []string{
+			"redis://localhost",
+			"hashmap://?sweep_interval=soon",
+			"hashmap://?unknown=1",
+		} {
+			if _, err := ParseDSN(dsn); err == nil {
+				t.Errorf("expected error parsing %s", dsn)
+			}
+		}
+	})
+
+	t.Run("Open", func(t *testing.T) {
+		db, err := hord.Open("hashmap://")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		defer db.Close()
+
+		if _, ok := db.(*Database); !ok {
+			t.Errorf("expected hashmap Database, got %T", db)
+		}
+	})
+}
+
+func TestReopenWithSweeper(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "reopen-sweeper.json")
+
+	db, err := Dial(Config{Filename: filename, SweepInterval: 10 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Each Close stops the sweeper, each Setup must allow it to start again
+	for i := 0; i < 3; i++ {
+		if err := db.Setup(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := db.SetWithTTL("expiring", []byte("value"), 20*time.Millisecond); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		<-time.After(100 * time.Millisecond)
+
+		db.RLock()
+		_, ok := db.data["expiring"]
+		db.RUnlock()
+		if ok {
+			t.Errorf("expected expired key to be swept from memory after re-open %d", i)
+		}
+		db.Close()
+	}
+}
+
+func TestWatchAfterReopen(t *testing.T) {
+	db, err := Dial(Config{Filename: filepath.Join(t.TempDir(), "watch.json")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := db.Setup(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	db.Close()
+
+	if err := db.Setup(); err != nil {
+		t.Fatalf("unexpected error re-opening: %v", err)
+	}
+	defer db.Close()
+
+	events, stop := db.Watch("a")
+	defer stop()
+
+	if err := db.Set("a", []byte("1")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	select {
+	case e, ok := <-events:
+		if !ok {
+			t.Fatalf("expected watch channel to be open after re-open")
+		}
+		if e.Type != hord.EventPut || e.Key != "a" || string(e.Value) != "1" {
+			t.Errorf("unexpected event %+v", e)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for event")
+	}
+}
+
+func TestVersionAfterReopen(t *testing.T) {
+	db, err := Dial(Config{Filename: filepath.Join(t.TempDir(), "version.json")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := db.Setup(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := db.Set("cas", []byte("v1")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, version, err := db.GetWithVersion("cas")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Reloading from the file must not change the version of unmodified keys
+	db.Close()
+	if err := db.Setup(); err != nil {
+		t.Fatalf("unexpected error re-opening: %v", err)
+	}
+	defer db.Close()
+
+	_, current, err := db.GetWithVersion("cas")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(current) != string(version) {
+		t.Errorf("expected version %x after re-open, got %x", version, current)
+	}
+
+	if err := db.SetIfVersion("cas", []byte("v2"), version); err != nil {
+		t.Errorf("unexpected error using version read before re-open: %v", err)
+	}
+	if err := db.DeleteIfVersion("cas", version); !errors.Is(err, hord.ErrConflict) {
+		t.Errorf("expected ErrConflict using stale version, got %v", err)
+	}
+}
+
+func TestTTLAfterReopen(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "ttl.json")
+	db, err := Dial(Config{Filename: filename, SweepInterval: 10 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := db.Setup(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := db.SetWithTTL("expiring", []byte("value"), 200*time.Millisecond); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := db.Set("persist", []byte("value")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	db.Close()
+
+	// A new instance loading the same file must still expire the key
+	db, err = Dial(Config{Filename: filename, SweepInterval: 10 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := db.Setup(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer db.Close()
+
+	if value, err := db.Get("expiring"); err != nil || string(value) != "value" {
+		t.Fatalf("unexpected value before TTL elapsed: %s, error: %v", value, err)
+	}
+
+	<-time.After(300 * time.Millisecond)
+
+	if _, err := db.Get("expiring"); !errors.Is(err, hord.ErrNil) {
+		t.Errorf("expected ErrNil after TTL elapsed, got: %v", err)
+	}
+	if value, err := db.Get("persist"); err != nil || string(value) != "value" {
+		t.Errorf("unexpected value: %s, error: %v", value, err)
+	}
+
+	db.RLock()
+	_, ok := db.data["expiring"]
+	db.RUnlock()
+	if ok {
+		t.Errorf("expected expired key to be swept from memory without a new SetWithTTL")
+	}
+
+	if _, err := os.Stat(filename + ".expires"); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected expirations file to be removed once no keys have a TTL, got: %v", err)
+	}
+}
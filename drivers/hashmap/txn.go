@@ -0,0 +1,85 @@
+package hashmap
+
+import (
+	"time"
+
+	"github.com/madflojo/hord"
+)
+
+// Txn executes fn within a transaction. Writes are staged in a copy-on-write overlay and applied to the hashmap only
+// if fn returns nil, leaving the stored data untouched when fn fails. The write lock is held while fn executes,
+// serializing transactions with all other operations; Database methods must not be called from within fn.
+func (db *Database) Txn(fn func(tx hord.Tx) error) error {
+	db.Lock()
+	defer db.Unlock()
+	if db.data == nil {
+		return hord.ErrNoDial
+	}
+
+	tx := &txn{db: db, writes: make(map[string][]byte)}
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	for k, v := range tx.writes {
+		if v == nil {
+			db.remove(k)
+			continue
+		}
+		db.put(k, v)
+		delete(db.expires, k)
+	}
+	return db.saveToLocalFile()
+}
+
+// txn is the hashmap implementation of hord.Tx.
+type txn struct {
+	db *Database
+
+	// writes holds staged writes, a nil value marks the key as deleted
+	writes map[string][]byte
+}
+
+// Get retrieves data within the transaction, returning staged writes before stored data.
+func (tx *txn) Get(key string) ([]byte, error) {
+	if err := hord.ValidKey(key); err != nil {
+		return []byte(""), err
+	}
+
+	if v, ok := tx.writes[key]; ok {
+		if v == nil {
+			return []byte(""), hord.ErrNil
+		}
+		return v, nil
+	}
+
+	v, ok := tx.db.data[key]
+	if ok && !tx.db.expired(key, time.Now()) {
+		return v, nil
+	}
+	return []byte(""), hord.ErrNil
+}
+
+// Set stages data to be written when the transaction commits.
+func (tx *txn) Set(key string, data []byte) error {
+	if err := hord.ValidKey(key); err != nil {
+		return err
+	}
+
+	if err := hord.ValidData(data); err != nil {
+		return err
+	}
+
+	tx.writes[key] = data
+	return nil
+}
+
+// Delete stages the key to be removed when the transaction commits.
+func (tx *txn) Delete(key string) error {
+	if err := hord.ValidKey(key); err != nil {
+		return err
+	}
+
+	tx.writes[key] = nil
+	return nil
+}
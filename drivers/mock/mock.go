@@ -61,6 +61,13 @@
 // the default behavior.
 package mock
 
+import (
+	"context"
+	"time"
+
+	"github.com/madflojo/hord"
+)
+
 // Config is passed to Dial to configure this mock. By default, mocked functions will return with a happy path scenario.
 // To override and customize the return use the appropriate functions defined within the Config struct.
 type Config struct {
@@ -82,6 +89,14 @@ type Config struct {
 
 	// KeysFunc allows users to define a custom function executed in place of the default Database Keys method.
 	KeysFunc func() ([]string, error)
+
+	// SetWithTTLFunc allows users to define a custom function executed in place of the default Database SetWithTTL
+	// method.
+	SetWithTTLFunc func(string, []byte, time.Duration) error
+
+	// CapabilitiesFunc allows users to define a custom function executed in place of the default Database
+	// Capabilities method.
+	CapabilitiesFunc func() hord.Capabilities
 }
 
 // Database is an object returned by the Dial function. This struct satisfies the Hord Database interface and can
@@ -105,6 +120,14 @@ type Database struct {
 
 	// keysFunc allows users to define a custom function executed in place of the default Database Keys method.
 	keysFunc func() ([]string, error)
+
+	// setWithTTLFunc allows users to define a custom function executed in place of the default Database SetWithTTL
+	// method.
+	setWithTTLFunc func(string, []byte, time.Duration) error
+
+	// capabilitiesFunc allows users to define a custom function executed in place of the default Database
+	// Capabilities method.
+	capabilitiesFunc func() hord.Capabilities
 }
 
 // Dial will mock connecting to a remote database. Users can use the returned Database object to fake interactions
@@ -140,6 +163,8 @@ func Dial(c Config) (*Database, error) {
 	db.setFunc = c.SetFunc
 	db.deleteFunc = c.DeleteFunc
 	db.keysFunc = c.KeysFunc
+	db.setWithTTLFunc = c.SetWithTTLFunc
+	db.capabilitiesFunc = c.CapabilitiesFunc
 	return db, nil
 }
 
@@ -179,6 +204,15 @@ func (db Database) Set(key string, data []byte) error {
 	return nil
 }
 
+// SetWithTTL provides a mocked function, which will return no error when executed without any configuration. If
+// Users have defined a custom SetWithTTL function, SetWithTTL will run the custom function producing the results.
+func (db Database) SetWithTTL(key string, data []byte, ttl time.Duration) error {
+	if db.setWithTTLFunc != nil {
+		return db.setWithTTLFunc(key, data, ttl)
+	}
+	return nil
+}
+
 // Delete provides a mocked function, which will return no error when executed without any configuration.
 // If Users have defined a custom Delete function, Delete will run the custom function producing the results.
 func (db Database) Delete(key string) error {
@@ -198,5 +232,69 @@ func (db Database) Keys() ([]string, error) {
 	return []string{}, nil
 }
 
+// SetupContext provides a context-aware version of Setup. If the context is already canceled, the context error is
+// returned; otherwise, SetupContext behaves the same as Setup.
+func (db Database) SetupContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return db.Setup()
+}
+
+// HealthCheckContext provides a context-aware version of HealthCheck. If the context is already canceled, the context
+// error is returned; otherwise, HealthCheckContext behaves the same as HealthCheck.
+func (db Database) HealthCheckContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return db.HealthCheck()
+}
+
+// GetContext provides a context-aware version of Get. If the context is already canceled, the context error is
+// returned; otherwise, GetContext behaves the same as Get.
+func (db Database) GetContext(ctx context.Context, key string) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return db.Get(key)
+}
+
+// SetContext provides a context-aware version of Set. If the context is already canceled, the context error is
+// returned; otherwise, SetContext behaves the same as Set.
+func (db Database) SetContext(ctx context.Context, key string, data []byte) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return db.Set(key, data)
+}
+
+// DeleteContext provides a context-aware version of Delete. If the context is already canceled, the context error is
+// returned; otherwise, DeleteContext behaves the same as Delete.
+func (db Database) DeleteContext(ctx context.Context, key string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return db.Delete(key)
+}
+
+// KeysContext provides a context-aware version of Keys. If the context is already canceled, the context error is
+// returned; otherwise, KeysContext behaves the same as Keys.
+func (db Database) KeysContext(ctx context.Context) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return db.Keys()
+}
+
+// Capabilities provides a mocked function, which will report the Context and TTL capabilities implemented by this
+// mock when executed without any configuration. If Users have defined a custom Capabilities function, Capabilities
+// will run the custom function producing the results.
+func (db Database) Capabilities() hord.Capabilities {
+	if db.capabilitiesFunc != nil {
+		return db.capabilitiesFunc()
+	}
+	return hord.Capabilities{Context: true, TTL: true}
+}
+
 // Close, when called, will return and not act. Use this function to mock a Close Database call.
 func (db Database) Close() {}
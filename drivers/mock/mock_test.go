@@ -1,9 +1,11 @@
 package mock
 
 import (
+	"context"
 	"fmt"
 	"github.com/madflojo/hord"
 	"testing"
+	"time"
 )
 
 func TestDefaults(t *testing.T) {
@@ -58,6 +60,13 @@ func TestDefaults(t *testing.T) {
 			t.Errorf("Keys mocked function did not work as expected returned %d values", len(keys))
 		}
 	})
+
+	t.Run("Validate Capabilities", func(t *testing.T) {
+		c := hord.CapabilitiesOf(db)
+		if !c.Context || !c.TTL {
+			t.Errorf("Capabilities mocked function did not work as expected returned %+v", c)
+		}
+	})
 }
 
 func TestMocking(t *testing.T) {
@@ -96,6 +105,17 @@ func TestMocking(t *testing.T) {
 		KeysFunc: func() ([]string, error) {
 			return []string{"key1", "key2"}, nil
 		},
+		// Create a fake SetWithTTL function
+		SetWithTTLFunc: func(key string, _ []byte, _ time.Duration) error {
+			if key == "works" {
+				return nil
+			}
+			return fmt.Errorf("Error inserting data")
+		},
+		// Create a fake Capabilities function
+		CapabilitiesFunc: func() hord.Capabilities {
+			return hord.Capabilities{Watch: true}
+		},
 	}
 
 	db, err := Dial(cfg)
@@ -160,6 +180,20 @@ func TestMocking(t *testing.T) {
 		}
 	})
 
+	t.Run("Validate SetWithTTL", func(t *testing.T) {
+		err := db.(hord.TTLDatabase).SetWithTTL("works", []byte{}, time.Second)
+		if err != nil {
+			t.Errorf("SetWithTTL mocked function did not work as expected err returned - %s", err)
+		}
+	})
+
+	t.Run("Validate SetWithTTL Errors", func(t *testing.T) {
+		err := db.(hord.TTLDatabase).SetWithTTL("doesntwork", []byte{}, time.Second)
+		if err == nil {
+			t.Errorf("SetWithTTL mocked function did not work as expected err returned - %s", err)
+		}
+	})
+
 	t.Run("Validate Keys", func(t *testing.T) {
 		keys, err := db.Keys()
 		if err != nil {
@@ -170,4 +204,55 @@ func TestMocking(t *testing.T) {
 		}
 	})
 
+	t.Run("Validate Capabilities", func(t *testing.T) {
+		c := hord.CapabilitiesOf(db)
+		if c != (hord.Capabilities{Watch: true}) {
+			t.Errorf("Capabilities mocked function did not work as expected returned %+v", c)
+		}
+	})
+
+}
+
+func TestContext(t *testing.T) {
+	var db hord.ContextDatabase
+	db, err := Dial(Config{
+		GetFunc: func(_ string) ([]byte, error) {
+			return []byte("Yes"), nil
+		},
+	})
+	if err != nil {
+		t.Errorf("Unexpected error when creating Mock interface - %s", err)
+	}
+	defer db.Close()
+
+	t.Run("Validate GetContext", func(t *testing.T) {
+		data, err := db.GetContext(context.Background(), "works")
+		if err != nil || string(data) != "Yes" {
+			t.Errorf("GetContext mocked function did not work as expected err returned - %s", err)
+		}
+	})
+
+	t.Run("Validate Canceled Context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		if err := db.SetupContext(ctx); err != context.Canceled {
+			t.Errorf("SetupContext did not return context error - %s", err)
+		}
+		if err := db.HealthCheckContext(ctx); err != context.Canceled {
+			t.Errorf("HealthCheckContext did not return context error - %s", err)
+		}
+		if _, err := db.GetContext(ctx, "works"); err != context.Canceled {
+			t.Errorf("GetContext did not return context error - %s", err)
+		}
+		if err := db.SetContext(ctx, "works", []byte("Yes")); err != context.Canceled {
+			t.Errorf("SetContext did not return context error - %s", err)
+		}
+		if err := db.DeleteContext(ctx, "works"); err != context.Canceled {
+			t.Errorf("DeleteContext did not return context error - %s", err)
+		}
+		if _, err := db.KeysContext(ctx); err != context.Canceled {
+			t.Errorf("KeysContext did not return context error - %s", err)
+		}
+	})
 }
@@ -0,0 +1,33 @@
+package nats
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/madflojo/hord"
+	"github.com/nats-io/nats.go"
+)
+
+// mapError maps errors returned by nats.go onto the common Hord errors, keeping the original error within the chain.
+// Errors without a Hord equivalent are returned unchanged.
+func mapError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, nats.ErrTimeout), errors.Is(err, context.DeadlineExceeded):
+		return fmt.Errorf("%w: %w", hord.ErrTimeout, err)
+	case errors.Is(err, nats.ErrConnectionClosed), errors.Is(err, nats.ErrConnectionDraining):
+		return fmt.Errorf("%w: %w", hord.ErrClosed, err)
+	case errors.Is(err, nats.ErrNoServers), errors.Is(err, nats.ErrNoResponders),
+		errors.Is(err, nats.ErrDisconnected), errors.Is(err, nats.ErrConnectionReconnecting),
+		errors.Is(err, nats.ErrStaleConnection), errors.Is(err, nats.ErrNoHeartbeat),
+		errors.Is(err, nats.ErrJetStreamNotEnabled), errors.Is(err, nats.ErrNoStreamResponse):
+		return fmt.Errorf("%w: %w", hord.ErrUnavailable, err)
+	case errors.Is(err, nats.ErrMaxPayload):
+		return fmt.Errorf("%w: %w", hord.ErrValueTooLarge, err)
+	case errors.Is(err, nats.ErrKeyExists):
+		return fmt.Errorf("%w: %w", hord.ErrConflict, err)
+	}
+	return err
+}
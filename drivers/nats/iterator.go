@@ -0,0 +1,75 @@
+package nats
+
+import (
+	"fmt"
+
+	"github.com/madflojo/hord"
+	"github.com/nats-io/nats.go"
+)
+
+// Iterator walks the keys stored within a NATS key-value store. Keys are delivered by a NATS watcher as the
+// iterator advances rather than being collected up front.
+type Iterator struct {
+	// lister is the underlying NATS key lister
+	lister nats.KeyLister
+
+	// key is the current key
+	key string
+}
+
+// IterateKeys returns an Iterator that walks all keys stored within the NATS key-value store.
+func (db *Database) IterateKeys() (hord.Iterator, error) {
+	db.RLock()
+	defer db.RUnlock()
+
+	// Check if the NATS key-value store is initialized
+	if db.kv == nil {
+		return nil, hord.ErrNoDial
+	}
+
+	lister, err := db.kv.ListKeys()
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch keys - %w", mapError(err))
+	}
+
+	return &Iterator{lister: lister}, nil
+}
+
+// Next advances the iterator to the next key delivered by NATS.
+func (i *Iterator) Next() bool {
+	if i.lister == nil {
+		return false
+	}
+
+	k, ok := <-i.lister.Keys()
+	if !ok {
+		return false
+	}
+	i.key = k
+	return true
+}
+
+// Key returns the current key.
+func (i *Iterator) Key() string {
+	return i.key
+}
+
+// Err always returns nil, as NATS does not report errors once key listing has started.
+func (i *Iterator) Err() error {
+	return nil
+}
+
+// Close stops the underlying NATS watcher.
+func (i *Iterator) Close() error {
+	if i.lister == nil {
+		return nil
+	}
+
+	// Stopping the lister unsubscribes the watcher, draining unblocks any pending key delivery so it can exit. The
+	// watcher may already be stopped once all keys are delivered, so errors from Stop are ignored.
+	_ = i.lister.Stop()
+	for range i.lister.Keys() {
+	}
+	i.lister = nil
+	return nil
+}
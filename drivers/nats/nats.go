@@ -59,10 +59,15 @@ Here are some examples demonstrating common usage patterns for the NATS driver.
 package nats
 
 import (
+	"context"
 	"crypto/tls"
+	"encoding/binary"
+	"errors"
 	"fmt"
 	"regexp"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/madflojo/hord"
 	"github.com/nats-io/nats.go"
@@ -79,6 +84,11 @@ type Config struct {
 	// to the `^[a-zA-Z0-9_-]+$` regex.
 	Bucket string
 
+	// TTL sets a bucket-wide expiration for keys. NATS key-value stores expire keys at the bucket level, when set,
+	// every key within the bucket will expire after the TTL elapses. This value only applies when the bucket is
+	// first created.
+	TTL time.Duration
+
 	// Servers enables connectivity to a cluster of NATS servers. Each entry must follow the NATS URL format.
 	Servers []string
 
@@ -104,6 +114,9 @@ type Database struct {
 
 	// kv provides a NATS key-value store
 	kv nats.KeyValue
+
+	// ttl is the bucket-wide TTL configured for the key-value store
+	ttl time.Duration
 }
 
 // reBucket is used to validate bucket names
@@ -112,7 +125,7 @@ var reBucket = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
 // Dial initializes and returns a new NATS database instance.
 func Dial(cfg Config) (*Database, error) {
 	var err error
-	db := &Database{}
+	db := &Database{ttl: cfg.TTL}
 
 	// Validate Bucket
 	if cfg.Bucket == "" || !reBucket.MatchString(cfg.Bucket) {
@@ -135,19 +148,19 @@ func Dial(cfg Config) (*Database, error) {
 	// Connect to the NATS server
 	db.conn, err = cfg.Options.Connect()
 	if err != nil {
-		return db, fmt.Errorf("unable to connect to NATS server - %s", err)
+		return db, fmt.Errorf("unable to connect to NATS server - %w", mapError(err))
 	}
 
 	// Create a JetStream context
 	js, err := db.conn.JetStream()
 	if err != nil {
-		return db, fmt.Errorf("unable to open JetStream - %s", err)
+		return db, fmt.Errorf("unable to open JetStream - %w", mapError(err))
 	}
 
 	// Create a key-value store within JetStream
-	db.kv, err = js.CreateKeyValue(&nats.KeyValueConfig{Bucket: cfg.Bucket})
+	db.kv, err = js.CreateKeyValue(&nats.KeyValueConfig{Bucket: cfg.Bucket, TTL: cfg.TTL})
 	if err != nil {
-		return db, fmt.Errorf("unable to open key-value store - %s", err)
+		return db, fmt.Errorf("unable to open key-value store - %w", mapError(err))
 	}
 
 	return db, nil
@@ -155,9 +168,14 @@ func Dial(cfg Config) (*Database, error) {
 
 // Setup sets up the nats database. This function does nothing for the nats driver.
 func (db *Database) Setup() error {
-	err := db.HealthCheck()
+	return db.SetupContext(context.Background())
+}
+
+// SetupContext is a context-aware version of Setup.
+func (db *Database) SetupContext(ctx context.Context) error {
+	err := db.HealthCheckContext(ctx)
 	if err != nil {
-		return fmt.Errorf("could not setup database, unhealthy - %s", err)
+		return fmt.Errorf("could not setup database, unhealthy - %w", err)
 	}
 	return nil
 }
@@ -165,6 +183,12 @@ func (db *Database) Setup() error {
 // Get retrieves data from the NATS database based on the provided key.
 // It returns the data associated with the key or an error if the key is invalid or the data does not exist.
 func (db *Database) Get(key string) ([]byte, error) {
+	return db.GetContext(context.Background(), key)
+}
+
+// GetContext is a context-aware version of Get. The NATS key-value API does not accept a context for this request,
+// so the context is only checked before the request is sent.
+func (db *Database) GetContext(ctx context.Context, key string) ([]byte, error) {
 	// Validate the key
 	if err := hord.ValidKey(key); err != nil {
 		return []byte(""), err
@@ -179,14 +203,19 @@ func (db *Database) Get(key string) ([]byte, error) {
 		return []byte(""), hord.ErrNoDial
 	}
 
+	// Check if the context expired before sending the request
+	if err := ctx.Err(); err != nil {
+		return []byte(""), fmt.Errorf("unable to fetch key - %w", mapError(err))
+	}
+
 	// Retrieve the value from the NATS key-value store
 	r, err := db.kv.Get(key)
 	if err != nil {
-		if err == nats.ErrKeyNotFound {
+		if errors.Is(err, nats.ErrKeyNotFound) {
 			// Return an error if the value is nil
 			return []byte(""), hord.ErrNil
 		}
-		return []byte(""), fmt.Errorf("unable to fetch key - %s", err)
+		return []byte(""), fmt.Errorf("unable to fetch key - %w", mapError(err))
 	}
 
 	return r.Value(), nil
@@ -195,6 +224,12 @@ func (db *Database) Get(key string) ([]byte, error) {
 // Set inserts or updates data in the NATS database based on the provided key.
 // It returns an error if the key or data is invalid.
 func (db *Database) Set(key string, data []byte) error {
+	return db.SetContext(context.Background(), key, data)
+}
+
+// SetContext is a context-aware version of Set. The NATS key-value API does not accept a context for this request,
+// so the context is only checked before the request is sent.
+func (db *Database) SetContext(ctx context.Context, key string, data []byte) error {
 	// Validate the key
 	if err := hord.ValidKey(key); err != nil {
 		return err
@@ -214,18 +249,78 @@ func (db *Database) Set(key string, data []byte) error {
 		return hord.ErrNoDial
 	}
 
+	// Check if the context expired before sending the request
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("unable to set key - %w", mapError(err))
+	}
+
 	// Insert or update the key-value pair in the NATS key-value store
 	_, err := db.kv.Put(key, data)
 	if err != nil {
-		return fmt.Errorf("unable to set key - %s", err)
+		return fmt.Errorf("unable to set key - %w", mapError(err))
 	}
 
 	return nil
 }
 
+// Create inserts data into the NATS database only if the provided key does not exist.
+// It returns hord.ErrKeyExists if the key is already present.
+func (db *Database) Create(key string, data []byte) error {
+	// Validate the key
+	if err := hord.ValidKey(key); err != nil {
+		return err
+	}
+
+	// Validate the data
+	if err := hord.ValidData(data); err != nil {
+		return err
+	}
+
+	// Acquire a write lock to ensure data consistency during insertion
+	db.Lock()
+	defer db.Unlock()
+
+	// Check if the NATS key-value store is initialized
+	if db.kv == nil {
+		return hord.ErrNoDial
+	}
+
+	// Create the key-value pair in the NATS key-value store
+	_, err := db.kv.Create(key, data)
+	if err != nil {
+		if errors.Is(err, nats.ErrKeyExists) {
+			return hord.ErrKeyExists
+		}
+		return fmt.Errorf("unable to create key - %w", mapError(err))
+	}
+
+	return nil
+}
+
+// SetWithTTL inserts or updates data in the NATS database with an expiration. NATS key-value stores only support
+// bucket-wide expiration, as such the provided TTL must match the TTL configured when dialing; any other TTL will
+// return hord.ErrNotSupported.
+func (db *Database) SetWithTTL(key string, data []byte, ttl time.Duration) error {
+	if err := hord.ValidTTL(ttl); err != nil {
+		return err
+	}
+
+	if db.ttl != ttl {
+		return fmt.Errorf("%w: NATS only supports the bucket-wide TTL of %s", hord.ErrNotSupported, db.ttl)
+	}
+
+	return db.Set(key, data)
+}
+
 // Delete removes data from the NATS database based on the provided key.
 // It returns an error if the key is invalid.
 func (db *Database) Delete(key string) error {
+	return db.DeleteContext(context.Background(), key)
+}
+
+// DeleteContext is a context-aware version of Delete. The NATS key-value API does not accept a context for this
+// request, so the context is only checked before the request is sent.
+func (db *Database) DeleteContext(ctx context.Context, key string) error {
 	// Validate the key
 	if err := hord.ValidKey(key); err != nil {
 		return err
@@ -240,10 +335,127 @@ func (db *Database) Delete(key string) error {
 		return hord.ErrNoDial
 	}
 
+	// Check if the context expired before sending the request
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("unable to remove key - %w", mapError(err))
+	}
+
 	// Delete the key from the NATS key-value store
 	err := db.kv.Delete(key)
 	if err != nil {
-		return fmt.Errorf("unable to remove key - %s", err)
+		return fmt.Errorf("unable to remove key - %w", mapError(err))
+	}
+
+	return nil
+}
+
+// GetWithVersion retrieves data from the NATS database along with the key's current Version. Versions are derived
+// from the NATS key-value revision of the entry.
+func (db *Database) GetWithVersion(key string) ([]byte, hord.Version, error) {
+	// Validate the key
+	if err := hord.ValidKey(key); err != nil {
+		return []byte(""), nil, err
+	}
+
+	// Acquire a read lock to ensure data consistency during retrieval
+	db.RLock()
+	defer db.RUnlock()
+
+	// Check if the NATS key-value store is initialized
+	if db.kv == nil {
+		return []byte(""), nil, hord.ErrNoDial
+	}
+
+	// Retrieve the entry from the NATS key-value store
+	r, err := db.kv.Get(key)
+	if err != nil {
+		if errors.Is(err, nats.ErrKeyNotFound) {
+			return []byte(""), nil, hord.ErrNil
+		}
+		return []byte(""), nil, fmt.Errorf("unable to fetch key - %w", mapError(err))
+	}
+
+	v := make(hord.Version, 8)
+	binary.BigEndian.PutUint64(v, r.Revision())
+	return r.Value(), v, nil
+}
+
+// SetIfVersion updates data in the NATS database only if the key's current Version matches the provided Version.
+// It returns hord.ErrConflict if the key has been modified or deleted, and hord.ErrInvalidVersion if the Version is not
+// a NATS revision.
+func (db *Database) SetIfVersion(key string, data []byte, version hord.Version) error {
+	// Validate the key
+	if err := hord.ValidKey(key); err != nil {
+		return err
+	}
+
+	// Validate the data
+	if err := hord.ValidData(data); err != nil {
+		return err
+	}
+
+	// Validate the version
+	if err := hord.ValidVersion(version); err != nil {
+		return err
+	}
+	if len(version) != 8 {
+		return fmt.Errorf("%w: NATS versions must be 8 bytes", hord.ErrInvalidVersion)
+	}
+
+	// Acquire a write lock to ensure data consistency during update
+	db.Lock()
+	defer db.Unlock()
+
+	// Check if the NATS key-value store is initialized
+	if db.kv == nil {
+		return hord.ErrNoDial
+	}
+
+	// Update the key only if the revision matches
+	_, err := db.kv.Update(key, data, binary.BigEndian.Uint64(version))
+	if err != nil {
+		if errors.Is(err, nats.ErrKeyExists) {
+			return hord.ErrConflict
+		}
+		return fmt.Errorf("unable to update key - %w", mapError(err))
+	}
+
+	return nil
+}
+
+// DeleteIfVersion removes data from the NATS database only if the key's current Version matches the provided
+// Version. It returns hord.ErrConflict if the key has been modified or deleted, and hord.ErrInvalidVersion if the
+// Version is not a NATS revision.
+func (db *Database) DeleteIfVersion(key string, version hord.Version) error {
+	// Validate the key
+	if err := hord.ValidKey(key); err != nil {
+		return err
+	}
+
+	// Validate the version
+	if err := hord.ValidVersion(version); err != nil {
+		return err
+	}
+	if len(version) != 8 {
+		return fmt.Errorf("%w: NATS versions must be 8 bytes", hord.ErrInvalidVersion)
+	}
+
+	// Acquire a write lock to ensure data consistency during deletion
+	db.Lock()
+	defer db.Unlock()
+
+	// Check if the NATS key-value store is initialized
+	if db.kv == nil {
+		return hord.ErrNoDial
+	}
+
+	// Delete the key only if the revision matches
+	err := db.kv.Delete(key, nats.LastRevision(binary.BigEndian.Uint64(version)))
+	if err != nil {
+		if errors.Is(err, nats.ErrKeyExists) {
+			return hord.ErrConflict
+		}
+		return fmt.Errorf("unable to remove key - %w", mapError(err))
 	}
 
 	return nil
@@ -251,6 +463,11 @@ func (db *Database) Delete(key string) error {
 
 // Keys retrieves a list of keys stored in the NATS database.
 func (db *Database) Keys() ([]string, error) {
+	return db.KeysContext(context.Background())
+}
+
+// KeysContext is a context-aware version of Keys.
+func (db *Database) KeysContext(ctx context.Context) ([]string, error) {
 	// Acquire a read lock to ensure data consistency during key retrieval
 	db.RLock()
 	defer db.RUnlock()
@@ -261,20 +478,167 @@ func (db *Database) Keys() ([]string, error) {
 	}
 
 	// Retrieve the keys from the NATS key-value store
-	keys, err := db.kv.Keys()
+	lister, err := db.kv.ListKeys(nats.Context(ctx))
 	if err != nil {
-		// If no keys, return empty list
-		if err == nats.ErrNoKeysFound {
-			return []string{}, nil
+		return []string{}, fmt.Errorf("unable to fetch keys - %w", mapError(err))
+	}
+	defer lister.Stop()
+
+	keys := []string{}
+	for {
+		select {
+		case <-ctx.Done():
+			// Stop listing keys if the context expires
+			return []string{}, fmt.Errorf("unable to fetch keys - %w", mapError(ctx.Err()))
+		case k, ok := <-lister.Keys():
+			if !ok {
+				return keys, nil
+			}
+			keys = append(keys, k)
 		}
-		return []string{}, fmt.Errorf("unable to fetch keys - %s", err)
+	}
+}
+
+// KeysWithPrefix retrieves a list of keys that start with the provided prefix. NATS keys are subjects, when the
+// prefix ends on a token boundary (a trailing "."), a subject wildcard is used to let the server filter keys.
+// Other prefixes are filtered client-side.
+func (db *Database) KeysWithPrefix(prefix string) ([]string, error) {
+	// Subject wildcards only match whole tokens, fall back to filtering all keys
+	if !strings.HasSuffix(prefix, ".") {
+		keys, err := db.Keys()
+		if err != nil {
+			return []string{}, err
+		}
+
+		results := []string{}
+		for _, k := range keys {
+			if strings.HasPrefix(k, prefix) {
+				results = append(results, k)
+			}
+		}
+		return results, nil
+	}
+
+	// Acquire a read lock to ensure data consistency during key retrieval
+	db.RLock()
+	defer db.RUnlock()
+
+	// Check if the NATS key-value store is initialized
+	if db.kv == nil {
+		return []string{}, hord.ErrNoDial
+	}
+
+	// Watch keys matching the subject wildcard, collecting keys until the initial values are delivered
+	w, err := db.kv.Watch(prefix+">", nats.IgnoreDeletes(), nats.MetaOnly())
+	if err != nil {
+		return []string{}, fmt.Errorf("unable to fetch keys - %w", mapError(err))
+	}
+	defer w.Stop()
+
+	keys := []string{}
+	for entry := range w.Updates() {
+		if entry == nil {
+			break
+		}
+		keys = append(keys, entry.Key())
 	}
 
 	return keys, nil
 }
 
+// Txn is not supported by the NATS driver as NATS key-value stores cannot apply writes to multiple keys atomically.
+// Txn always returns hord.ErrNotSupported without calling fn.
+func (db *Database) Txn(fn func(tx hord.Tx) error) error {
+	return fmt.Errorf("%w: NATS key-value stores do not support transactions", hord.ErrNotSupported)
+}
+
+// Watch returns a channel of events for changes to the provided key and any keys that start with it, using a NATS
+// key-value watcher. Only changes made after Watch is called are delivered. When the key or prefix ends on a token
+// boundary (a trailing "."), a subject wildcard is used to let the server filter keys; otherwise, events are filtered
+// client-side. The channel is closed when stop is called, the database is closed, or the watcher cannot be created.
+func (db *Database) Watch(keyOrPrefix string) (<-chan hord.Event, func()) {
+	ch := make(chan hord.Event)
+
+	// Acquire a read lock to ensure data consistency while creating the watcher
+	db.RLock()
+	defer db.RUnlock()
+
+	// Check if the NATS key-value store is initialized
+	if db.kv == nil {
+		close(ch)
+		return ch, func() {}
+	}
+
+	// Subject wildcards only match whole tokens, fall back to watching all keys
+	subject := ">"
+	if strings.HasSuffix(keyOrPrefix, ".") {
+		subject = keyOrPrefix + ">"
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	w, err := db.kv.Watch(subject, nats.UpdatesOnly(), nats.Context(ctx))
+	if err != nil {
+		cancel()
+		close(ch)
+		return ch, func() {}
+	}
+
+	go func() {
+		defer close(ch)
+		defer w.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case entry, ok := <-w.Updates():
+				if !ok {
+					return
+				}
+				if entry == nil || !strings.HasPrefix(entry.Key(), keyOrPrefix) {
+					continue
+				}
+
+				e := hord.Event{Type: hord.EventPut, Key: entry.Key(), Value: entry.Value()}
+				if entry.Operation() != nats.KeyValuePut {
+					e.Type = hord.EventDelete
+					e.Value = nil
+				}
+
+				select {
+				case ch <- e:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return ch, cancel
+}
+
+// Capabilities returns the optional features supported by the NATS driver. NATS key-value stores only support a
+// bucket-wide TTL and cannot write multiple keys atomically, so per-key TTLs, batches, and transactions are not
+// supported.
+func (db *Database) Capabilities() hord.Capabilities {
+	return hord.Capabilities{
+		Context:        true,
+		Iterate:        true,
+		Prefix:         true,
+		CompareAndSwap: true,
+		Create:         true,
+		Watch:          true,
+	}
+}
+
 // HealthCheck performs a health check on the NATS database.
 func (db *Database) HealthCheck() error {
+	return db.HealthCheckContext(context.Background())
+}
+
+// HealthCheckContext is a context-aware version of HealthCheck. The NATS key-value API does not accept a context for
+// this request, so the context is only checked before the request is sent.
+func (db *Database) HealthCheckContext(ctx context.Context) error {
 	// Acquire a read lock to ensure data consistency during health check
 	db.RLock()
 	defer db.RUnlock()
@@ -284,10 +648,15 @@ func (db *Database) HealthCheck() error {
 		return hord.ErrNoDial
 	}
 
+	// Check if the context expired before sending the request
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("kv store unhealthy - %w", mapError(err))
+	}
+
 	// Check the status of the NATS key-value store
 	_, err := db.kv.Status()
 	if err != nil {
-		return fmt.Errorf("kv store unhealthy - %s", err)
+		return fmt.Errorf("kv store unhealthy - %w", mapError(err))
 	}
 
 	return nil
@@ -0,0 +1,53 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/madflojo/hord"
+)
+
+// errPoolClosed matches the unexported error returned by redigo when a connection is requested from a closed pool.
+const errPoolClosed = "redigo: get on closed pool"
+
+// unavailable lists the Redis error prefixes returned while a server cannot serve requests.
+var unavailable = []string{"LOADING", "MASTERDOWN", "CLUSTERDOWN", "TRYAGAIN", "BUSY"}
+
+// mapError maps errors returned by redigo onto the common Hord errors, keeping the original error within the chain.
+// Errors without a Hord equivalent are returned unchanged.
+func mapError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var netErr net.Error
+	var redisErr redis.Error
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return fmt.Errorf("%w: %w", hord.ErrTimeout, err)
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return fmt.Errorf("%w: %w", hord.ErrTimeout, err)
+	case err.Error() == errPoolClosed:
+		return fmt.Errorf("%w: %w", hord.ErrClosed, err)
+	case errors.Is(err, redis.ErrPoolExhausted), errors.As(err, &netErr),
+		errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		return fmt.Errorf("%w: %w", hord.ErrUnavailable, err)
+	case errors.As(err, &redisErr):
+		msg := redisErr.Error()
+		if strings.Contains(msg, "exceeds maximum allowed size") {
+			return fmt.Errorf("%w: %w", hord.ErrValueTooLarge, err)
+		}
+		for _, prefix := range unavailable {
+			if strings.HasPrefix(msg, prefix) {
+				return fmt.Errorf("%w: %w", hord.ErrUnavailable, err)
+			}
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,154 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/madflojo/hord"
+)
+
+// scanCount is the number of keys requested from Redis with each SCAN command.
+const scanCount = 1000
+
+// Iterator walks the Redis keyspace using the SCAN command. Keys are fetched in batches as the iterator advances,
+// avoiding the server blocking behavior of the KEYS command. Like SCAN, the Iterator may return a key more than once
+// and keys added or removed during iteration may or may not be returned.
+type Iterator struct {
+	// ctx bounds the connection and SCAN command used to fetch each batch of keys
+	ctx context.Context
+
+	// pool is the Redis connection pool used to fetch batches of keys
+	pool *redis.Pool
+
+	// cursor is the SCAN cursor for the next batch of keys
+	cursor string
+
+	// match is an optional SCAN MATCH pattern used to filter keys
+	match string
+
+	// done is set once Redis returns a zero cursor
+	done bool
+
+	// keys holds the current batch of keys
+	keys []string
+
+	// key is the current key
+	key string
+
+	// err holds any error encountered while scanning
+	err error
+}
+
+// IterateKeys returns an Iterator that walks all keys stored within Redis using SCAN.
+func (db *Database) IterateKeys() (hord.Iterator, error) {
+	if db == nil || db.pool == nil {
+		return nil, hord.ErrNoDial
+	}
+	return &Iterator{ctx: context.Background(), pool: db.pool, cursor: "0"}, nil
+}
+
+// Next advances the iterator, fetching the next batch of keys from Redis when needed.
+func (i *Iterator) Next() bool {
+	for len(i.keys) == 0 {
+		if i.done || i.err != nil || i.pool == nil {
+			return false
+		}
+		i.scan()
+	}
+
+	i.key = i.keys[0]
+	i.keys = i.keys[1:]
+	return true
+}
+
+// scan fetches the next batch of keys from Redis.
+func (i *Iterator) scan() {
+	c, err := i.pool.GetContext(i.ctx)
+	if err != nil {
+		i.err = fmt.Errorf("unable to scan keys from Redis - %w", mapError(err))
+		return
+	}
+	defer c.Close()
+
+	args := redis.Args{}.Add(i.cursor, "COUNT", scanCount)
+	if i.match != "" {
+		args = args.Add("MATCH", i.match)
+	}
+
+	values, err := redis.Values(redis.DoContext(c, i.ctx, "SCAN", args...))
+	if err != nil {
+		i.err = fmt.Errorf("unable to scan keys from Redis - %w", mapError(err))
+		return
+	}
+
+	var keys []string
+	_, err = redis.Scan(values, &i.cursor, &keys)
+	if err != nil {
+		i.err = fmt.Errorf("unable to parse scan results from Redis - %w", mapError(err))
+		return
+	}
+
+	i.keys = keys
+	if i.cursor == "0" {
+		i.done = true
+	}
+}
+
+// Key returns the current key.
+func (i *Iterator) Key() string {
+	return i.key
+}
+
+// Err returns any error encountered while scanning.
+func (i *Iterator) Err() error {
+	return i.err
+}
+
+// Close stops the iterator. Connections are only held while fetching a batch, so there is nothing to release.
+func (i *Iterator) Close() error {
+	i.pool = nil
+	i.keys = nil
+	return nil
+}
+
+// KeysWithPrefix is called to retrieve a list of keys that start with the provided prefix. This function uses SCAN
+// with a MATCH pattern, avoiding the server blocking behavior of the KEYS command.
+func (db *Database) KeysWithPrefix(prefix string) ([]string, error) {
+	if db == nil || db.pool == nil {
+		return []string{}, hord.ErrNoDial
+	}
+
+	return db.scanKeys(context.Background(), escapePattern(prefix)+"*")
+}
+
+// scanKeys collects all keys matching the SCAN MATCH pattern, or all keys when match is empty.
+func (db *Database) scanKeys(ctx context.Context, match string) ([]string, error) {
+	iter := &Iterator{ctx: ctx, pool: db.pool, cursor: "0", match: match}
+	defer iter.Close()
+
+	// SCAN may return a key more than once, de-duplicate results
+	seen := make(map[string]struct{})
+	keys := []string{}
+	for iter.Next() {
+		if _, ok := seen[iter.Key()]; ok {
+			continue
+		}
+		seen[iter.Key()] = struct{}{}
+		keys = append(keys, iter.Key())
+	}
+	if err := iter.Err(); err != nil {
+		return []string{}, err
+	}
+
+	return keys, nil
+}
+
+// patternEscaper escapes Redis glob-style pattern characters.
+var patternEscaper = strings.NewReplacer(`\`, `\\`, `*`, `\*`, `?`, `\?`, `[`, `\[`, `]`, `\]`)
+
+// escapePattern escapes any glob-style pattern characters within s so it can be matched literally.
+func escapePattern(s string) string {
+	return patternEscaper.Replace(s)
+}
@@ -57,11 +57,15 @@ Hord provides a simple abstraction for working with Redis, with easy-to-use meth
 package redis
 
 import (
+	"context"
+	"crypto/sha1"
 	"crypto/tls"
+	"encoding/hex"
 	"fmt"
 	"github.com/FZambia/sentinel"
 	"github.com/gomodule/redigo/redis"
 	"github.com/madflojo/hord"
+	"sync"
 	"time"
 )
 
@@ -112,6 +116,10 @@ type Config struct {
 	// and can be used to configure 2-way TLS for Redis and Redis Sentinel.
 	TLSConfig *tls.Config
 
+	// Username specifies the ACL user to authenticate as with Redis 6 and newer. If blank, AUTH is performed with the
+	// Password only.
+	Username string
+
 	// WriteTimeout is used to specify a global write timeout for each Redis command.
 	WriteTimeout time.Duration
 }
@@ -136,6 +144,12 @@ type Database struct {
 
 	// sentinel holds the Redis sentinel connections
 	sentinel *sentinel.Sentinel
+
+	// closed is closed when the Database is closed, stopping any active watchers
+	closed chan struct{}
+
+	// closer ensures closed is only closed once
+	closer sync.Once
 }
 
 // Dial will establish a Redis connection pool using the configuration provided. It provides back an interface that
@@ -143,6 +157,7 @@ type Database struct {
 func Dial(conf Config) (*Database, error) {
 	db := &Database{
 		config: conf,
+		closed: make(chan struct{}),
 	}
 
 	// Verify that Either Server or Sentinel Servers is set
@@ -156,6 +171,9 @@ func Dial(conf Config) (*Database, error) {
 	opts = append(opts, redis.DialDatabase(db.config.Database))
 	opts = append(opts, redis.DialKeepAlive(db.config.KeepAlive))
 	opts = append(opts, redis.DialPassword(db.config.Password))
+	if db.config.Username != "" {
+		opts = append(opts, redis.DialUsername(db.config.Username))
+	}
 	opts = append(opts, redis.DialReadTimeout(db.config.ReadTimeout))
 	opts = append(opts, redis.DialWriteTimeout(db.config.WriteTimeout))
 	if db.config.TLSConfig != nil {
@@ -211,7 +229,7 @@ func Dial(conf Config) (*Database, error) {
 			}
 			_, err := c.Do("PING")
 			if err != nil {
-				return fmt.Errorf("connection is unhealthy, failed ping %s", err)
+				return fmt.Errorf("connection is unhealthy, failed ping - %w", err)
 			}
 			return nil
 		},
@@ -220,7 +238,7 @@ func Dial(conf Config) (*Database, error) {
 	// Execute HealthCheck to verify connectivity
 	err := db.HealthCheck()
 	if err != nil {
-		return db, fmt.Errorf("connection is unhealthy, failed ping %s", err)
+		return db, fmt.Errorf("connection is unhealthy, failed ping - %w", err)
 	}
 
 	return db, nil
@@ -228,10 +246,15 @@ func Dial(conf Config) (*Database, error) {
 
 // Setup does nothing with Redis, this is only here to meet interface requirements.
 func (db *Database) Setup() error {
+	return db.SetupContext(context.Background())
+}
+
+// SetupContext is a context-aware version of Setup.
+func (db *Database) SetupContext(ctx context.Context) error {
 	// Execute HealthCheck to verify connectivity
-	err := db.HealthCheck()
+	err := db.HealthCheckContext(ctx)
 	if err != nil {
-		return fmt.Errorf("connection is unhealthy, failed ping %s", err)
+		return fmt.Errorf("connection is unhealthy, failed ping - %w", err)
 	}
 	return nil
 }
@@ -239,6 +262,12 @@ func (db *Database) Setup() error {
 // Get is called to retrieve data from the database. This function will take in a key and return
 // the data or any errors received from querying the database.
 func (db *Database) Get(key string) ([]byte, error) {
+	return db.GetContext(context.Background(), key)
+}
+
+// GetContext is a context-aware version of Get. The context is used when obtaining a connection from the pool
+// and while executing the Redis command.
+func (db *Database) GetContext(ctx context.Context, key string) ([]byte, error) {
 	if err := hord.ValidKey(key); err != nil {
 		return nil, err
 	}
@@ -247,12 +276,15 @@ func (db *Database) Get(key string) ([]byte, error) {
 		return nil, hord.ErrNoDial
 	}
 
-	c := db.pool.Get()
+	c, err := db.pool.GetContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch data from Redis - %w", mapError(err))
+	}
 	defer c.Close()
 
-	d, err := redis.Bytes(c.Do("GET", key))
+	d, err := redis.Bytes(redis.DoContext(c, ctx, "GET", key))
 	if err != nil && err != redis.ErrNil {
-		return nil, fmt.Errorf("unable to fetch data from Redis - %s", err)
+		return nil, fmt.Errorf("unable to fetch data from Redis - %w", mapError(err))
 	}
 	if err == redis.ErrNil {
 		return []byte(""), hord.ErrNil
@@ -264,6 +296,40 @@ func (db *Database) Get(key string) ([]byte, error) {
 // Set is called when data within the database needs to be updated or inserted. This function will
 // take the data provided and create an entry within the database using the key as a lookup value.
 func (db *Database) Set(key string, data []byte) error {
+	return db.SetContext(context.Background(), key, data)
+}
+
+// SetContext is a context-aware version of Set.
+func (db *Database) SetContext(ctx context.Context, key string, data []byte) error {
+	if err := hord.ValidKey(key); err != nil {
+		return err
+	}
+
+	if err := hord.ValidData(data); err != nil {
+		return err
+	}
+
+	if db == nil || db.pool == nil {
+		return hord.ErrNoDial
+	}
+
+	c, err := db.pool.GetContext(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to write data to Redis - %w", mapError(err))
+	}
+	defer c.Close()
+
+	_, err = redis.DoContext(c, ctx, "SET", key, data)
+	if err != nil {
+		return fmt.Errorf("unable to write data to Redis - %w", mapError(err))
+	}
+
+	return nil
+}
+
+// Create is called when data needs to be inserted only if the key does not already exist. This function uses the
+// Redis SET NX option, returning hord.ErrKeyExists if the key is already present.
+func (db *Database) Create(key string, data []byte) error {
 	if err := hord.ValidKey(key); err != nil {
 		return err
 	}
@@ -279,9 +345,45 @@ func (db *Database) Set(key string, data []byte) error {
 	c := db.pool.Get()
 	defer c.Close()
 
-	_, err := c.Do("SET", key, data)
+	_, err := redis.String(c.Do("SET", key, data, "NX"))
+	if err == redis.ErrNil {
+		return hord.ErrKeyExists
+	}
 	if err != nil {
-		return fmt.Errorf("unable to write data to Redis - %s", err)
+		return fmt.Errorf("unable to write data to Redis - %w", mapError(err))
+	}
+
+	return nil
+}
+
+// SetWithTTL is called when data within the database needs to be updated or inserted with an expiration. This
+// function uses the Redis SET command with the PX option, allowing Redis to expire the key once the TTL elapses.
+func (db *Database) SetWithTTL(key string, data []byte, ttl time.Duration) error {
+	if err := hord.ValidKey(key); err != nil {
+		return err
+	}
+
+	if err := hord.ValidData(data); err != nil {
+		return err
+	}
+
+	if err := hord.ValidTTL(ttl); err != nil {
+		return err
+	}
+
+	if db == nil || db.pool == nil {
+		return hord.ErrNoDial
+	}
+
+	c := db.pool.Get()
+	defer c.Close()
+
+	// Redis expirations have millisecond precision, round partial milliseconds up so keys never expire early
+	ms := int64((ttl + time.Millisecond - 1) / time.Millisecond)
+
+	_, err := c.Do("SET", key, data, "PX", ms)
+	if err != nil {
+		return fmt.Errorf("unable to write data to Redis - %w", mapError(err))
 	}
 
 	return nil
@@ -290,10 +392,170 @@ func (db *Database) Set(key string, data []byte) error {
 // Delete is called when data within the database needs to be deleted. This function will delete
 // the data stored within the database for the specified key.
 func (db *Database) Delete(key string) error {
+	return db.DeleteContext(context.Background(), key)
+}
+
+// DeleteContext is a context-aware version of Delete.
+func (db *Database) DeleteContext(ctx context.Context, key string) error {
+	if err := hord.ValidKey(key); err != nil {
+		return err
+	}
+
+	if db == nil || db.pool == nil {
+		return hord.ErrNoDial
+	}
+
+	c, err := db.pool.GetContext(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to remove key from Redis - %w", mapError(err))
+	}
+	defer c.Close()
+
+	_, err = redis.DoContext(c, ctx, "DEL", key)
+	if err != nil {
+		return fmt.Errorf("unable to remove key from Redis - %w", mapError(err))
+	}
+
+	return nil
+}
+
+// GetMany is called to retrieve data for multiple keys using a single Redis MGET command. Keys that do not exist are
+// omitted from the results.
+func (db *Database) GetMany(keys []string) (map[string][]byte, error) {
+	if err := hord.ValidKeys(keys); err != nil {
+		return nil, err
+	}
+
+	if db == nil || db.pool == nil {
+		return nil, hord.ErrNoDial
+	}
+
+	results := make(map[string][]byte, len(keys))
+	if len(keys) == 0 {
+		return results, nil
+	}
+
+	c := db.pool.Get()
+	defer c.Close()
+
+	args := redis.Args{}.AddFlat(keys)
+	values, err := redis.ByteSlices(c.Do("MGET", args...))
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch data from Redis - %w", mapError(err))
+	}
+
+	// MGET returns values in the same order as the requested keys, with nil for missing keys
+	for i, v := range values {
+		if v != nil {
+			results[keys[i]] = v
+		}
+	}
+
+	return results, nil
+}
+
+// SetMany is called to insert or update multiple keys using a single Redis MSET command. MSET is atomic, either
+// all keys are set or none are.
+func (db *Database) SetMany(items map[string][]byte) error {
+	if err := hord.ValidItems(items); err != nil {
+		return err
+	}
+
+	if db == nil || db.pool == nil {
+		return hord.ErrNoDial
+	}
+
+	if len(items) == 0 {
+		return nil
+	}
+
+	c := db.pool.Get()
+	defer c.Close()
+
+	args := redis.Args{}
+	for k, v := range items {
+		args = args.Add(k, v)
+	}
+
+	_, err := c.Do("MSET", args...)
+	if err != nil {
+		return fmt.Errorf("unable to write data to Redis - %w", mapError(err))
+	}
+
+	return nil
+}
+
+// DeleteMany is called to delete multiple keys using a single Redis DEL command.
+func (db *Database) DeleteMany(keys []string) error {
+	if err := hord.ValidKeys(keys); err != nil {
+		return err
+	}
+
+	if db == nil || db.pool == nil {
+		return hord.ErrNoDial
+	}
+
+	if len(keys) == 0 {
+		return nil
+	}
+
+	c := db.pool.Get()
+	defer c.Close()
+
+	_, err := c.Do("DEL", redis.Args{}.AddFlat(keys)...)
+	if err != nil {
+		return fmt.Errorf("unable to remove keys from Redis - %w", mapError(err))
+	}
+
+	return nil
+}
+
+// setIfVersion is a Lua script that sets KEYS[1] to ARGV[2] only if the SHA-1 of the current value matches ARGV[1].
+var setIfVersion = redis.NewScript(1, `
+local v = redis.call("GET", KEYS[1])
+if v and redis.sha1hex(v) == ARGV[1] then
+	redis.call("SET", KEYS[1], ARGV[2])
+	return 1
+end
+return 0
+`)
+
+// deleteIfVersion is a Lua script that deletes KEYS[1] only if the SHA-1 of the current value matches ARGV[1].
+var deleteIfVersion = redis.NewScript(1, `
+local v = redis.call("GET", KEYS[1])
+if v and redis.sha1hex(v) == ARGV[1] then
+	redis.call("DEL", KEYS[1])
+	return 1
+end
+return 0
+`)
+
+// GetWithVersion is called to retrieve data from the database along with the key's current Version. Versions are
+// derived from a SHA-1 hash of the stored data, allowing the comparison to be performed server-side.
+func (db *Database) GetWithVersion(key string) ([]byte, hord.Version, error) {
+	d, err := db.Get(key)
+	if err != nil {
+		return d, nil, err
+	}
+	return d, version(d), nil
+}
+
+// SetIfVersion is called to update data within the database only if the key's current Version matches the provided
+// Version. The comparison and update are performed atomically using a Lua script. If the key has been modified or
+// deleted, hord.ErrConflict is returned.
+func (db *Database) SetIfVersion(key string, data []byte, v hord.Version) error {
 	if err := hord.ValidKey(key); err != nil {
 		return err
 	}
 
+	if err := hord.ValidData(data); err != nil {
+		return err
+	}
+
+	if err := hord.ValidVersion(v); err != nil {
+		return err
+	}
+
 	if db == nil || db.pool == nil {
 		return hord.ErrNoDial
 	}
@@ -301,46 +563,108 @@ func (db *Database) Delete(key string) error {
 	c := db.pool.Get()
 	defer c.Close()
 
-	_, err := c.Do("DEL", key)
+	ok, err := redis.Bool(setIfVersion.Do(c, key, string(v), data))
 	if err != nil {
-		return fmt.Errorf("unable to remove key from Redis - %s", err)
+		return fmt.Errorf("unable to write data to Redis - %w", mapError(err))
+	}
+	if !ok {
+		return hord.ErrConflict
 	}
 
 	return nil
 }
 
-// Keys is called to retrieve a list of keys stored within the database. This function will query
-// the database returning all keys used within the hord database.
-func (db *Database) Keys() ([]string, error) {
+// DeleteIfVersion is called to delete data within the database only if the key's current Version matches the
+// provided Version. The comparison and delete are performed atomically using a Lua script. If the key has been
+// modified or deleted, hord.ErrConflict is returned.
+func (db *Database) DeleteIfVersion(key string, v hord.Version) error {
+	if err := hord.ValidKey(key); err != nil {
+		return err
+	}
+
+	if err := hord.ValidVersion(v); err != nil {
+		return err
+	}
+
 	if db == nil || db.pool == nil {
-		return []string{}, hord.ErrNoDial
+		return hord.ErrNoDial
 	}
+
 	c := db.pool.Get()
 	defer c.Close()
 
-	keys, err := redis.Strings(c.Do("KEYS", "*"))
+	ok, err := redis.Bool(deleteIfVersion.Do(c, key, string(v)))
 	if err != nil {
-		return keys, fmt.Errorf("unable to fetch keys from Redis - %s", err)
+		return fmt.Errorf("unable to delete data from Redis - %w", mapError(err))
+	}
+	if !ok {
+		return hord.ErrConflict
 	}
 
-	return keys, nil
+	return nil
+}
+
+// version returns a Version derived from the hex encoded SHA-1 hash of the provided data, matching the output of the
+// Lua redis.sha1hex function.
+func version(data []byte) hord.Version {
+	sum := sha1.Sum(data)
+	return hord.Version(hex.EncodeToString(sum[:]))
+}
+
+// Keys is called to retrieve a list of keys stored within the database. This function will query
+// the database returning all keys used within the hord database. Keys are collected with SCAN, avoiding the server
+// blocking behavior of the KEYS command.
+func (db *Database) Keys() ([]string, error) {
+	return db.KeysContext(context.Background())
+}
+
+// KeysContext is a context-aware version of Keys.
+func (db *Database) KeysContext(ctx context.Context) ([]string, error) {
+	if db == nil || db.pool == nil {
+		return []string{}, hord.ErrNoDial
+	}
+	return db.scanKeys(ctx, "")
+}
+
+// Capabilities returns the optional features supported by the Redis driver. Redis does not store keys in sorted
+// order, so Range queries are not supported.
+func (db *Database) Capabilities() hord.Capabilities {
+	return hord.Capabilities{
+		Context:        true,
+		TTL:            true,
+		Batch:          true,
+		Iterate:        true,
+		Prefix:         true,
+		CompareAndSwap: true,
+		Create:         true,
+		Watch:          true,
+		Txn:            true,
+	}
 }
 
 // HealthCheck is used to verify connectivity and health of the database. This function
 // simply runs a generic ping against the database. If the ping errors in any fashion this
 // function will return an error.
 func (db *Database) HealthCheck() error {
+	return db.HealthCheckContext(context.Background())
+}
+
+// HealthCheckContext is a context-aware version of HealthCheck.
+func (db *Database) HealthCheckContext(ctx context.Context) error {
 	// Return error if pool is not created
 	if db == nil || db.pool == nil {
 		return hord.ErrNoDial
 	}
 
-	c := db.pool.Get()
+	c, err := db.pool.GetContext(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to ping Redis - %w", mapError(err))
+	}
 	defer c.Close()
 
-	_, err := c.Do("PING")
+	_, err = redis.DoContext(c, ctx, "PING")
 	if err != nil {
-		return fmt.Errorf("unable to ping Redis - %s", err)
+		return fmt.Errorf("unable to ping Redis - %w", mapError(err))
 	}
 	return nil
 }
@@ -351,6 +675,9 @@ func (db *Database) Close() {
 		return
 	}
 	defer db.pool.Close()
+	db.closer.Do(func() {
+		close(db.closed)
+	})
 	if db.sentinel != nil {
 		defer db.sentinel.Close()
 	}
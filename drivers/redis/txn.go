@@ -0,0 +1,122 @@
+package redis
+
+import (
+	"fmt"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/madflojo/hord"
+)
+
+// Txn executes fn within a Redis transaction. Keys read through tx are watched with WATCH, and writes are queued and
+// applied with MULTI/EXEC once fn returns nil. If a watched key is modified by another client before the writes are
+// applied, no writes are made and hord.ErrConflict is returned, allowing the caller to retry.
+func (db *Database) Txn(fn func(tx hord.Tx) error) error {
+	if db == nil || db.pool == nil {
+		return hord.ErrNoDial
+	}
+
+	c := db.pool.Get()
+	defer c.Close()
+
+	tx := &txn{conn: c, writes: make(map[string][]byte)}
+	if err := fn(tx); err != nil {
+		_, _ = c.Do("UNWATCH")
+		return err
+	}
+
+	if len(tx.writes) == 0 {
+		_, err := c.Do("UNWATCH")
+		if err != nil {
+			return fmt.Errorf("unable to execute transaction - %w", mapError(err))
+		}
+		return nil
+	}
+
+	// Queue writes and execute
+	err := c.Send("MULTI")
+	if err != nil {
+		return fmt.Errorf("unable to execute transaction - %w", mapError(err))
+	}
+	for k, v := range tx.writes {
+		if v == nil {
+			err = c.Send("DEL", k)
+		} else {
+			err = c.Send("SET", k, v)
+		}
+		if err != nil {
+			return fmt.Errorf("unable to execute transaction - %w", mapError(err))
+		}
+	}
+
+	_, err = redis.Values(c.Do("EXEC"))
+	if err == redis.ErrNil {
+		return hord.ErrConflict
+	}
+	if err != nil {
+		return fmt.Errorf("unable to execute transaction - %w", mapError(err))
+	}
+
+	return nil
+}
+
+// txn is the Redis implementation of hord.Tx.
+type txn struct {
+	// conn is the connection used for the lifetime of the transaction
+	conn redis.Conn
+
+	// writes holds queued writes, a nil value marks the key as deleted
+	writes map[string][]byte
+}
+
+// Get watches and retrieves the key, returning queued writes before stored data.
+func (tx *txn) Get(key string) ([]byte, error) {
+	if err := hord.ValidKey(key); err != nil {
+		return nil, err
+	}
+
+	if v, ok := tx.writes[key]; ok {
+		if v == nil {
+			return []byte(""), hord.ErrNil
+		}
+		return v, nil
+	}
+
+	_, err := tx.conn.Do("WATCH", key)
+	if err != nil {
+		return nil, fmt.Errorf("unable to watch key - %w", mapError(err))
+	}
+
+	d, err := redis.Bytes(tx.conn.Do("GET", key))
+	if err == redis.ErrNil {
+		return []byte(""), hord.ErrNil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch data from Redis - %w", mapError(err))
+	}
+
+	return d, nil
+}
+
+// Set queues data to be written when the transaction executes.
+func (tx *txn) Set(key string, data []byte) error {
+	if err := hord.ValidKey(key); err != nil {
+		return err
+	}
+
+	if err := hord.ValidData(data); err != nil {
+		return err
+	}
+
+	tx.writes[key] = data
+	return nil
+}
+
+// Delete queues the key to be deleted when the transaction executes.
+func (tx *txn) Delete(key string) error {
+	if err := hord.ValidKey(key); err != nil {
+		return err
+	}
+
+	tx.writes[key] = nil
+	return nil
+}
@@ -0,0 +1,100 @@
+package redis
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/madflojo/hord"
+)
+
+// Watch returns a channel of events for changes to the provided key and any keys that start with it, using Redis
+// keyspace notifications. Keyspace notifications are disabled by default and must be enabled on the Redis server
+// for string, generic, expired, and evicted events (i.e. `notify-keyspace-events K$gxe`).
+//
+// Keyspace notifications do not include data; the current value of a key is read when a set event is received,
+// keys removed before the read completes are skipped. Redis Pub/Sub is fire-and-forget, events published while the
+// connection is interrupted are lost. The channel is closed when stop is called, the database is closed, or the
+// subscription fails.
+func (db *Database) Watch(keyOrPrefix string) (<-chan hord.Event, func()) {
+	ch := make(chan hord.Event)
+	if db == nil || db.pool == nil {
+		close(ch)
+		return ch, func() {}
+	}
+
+	// Subscribe to keyspace notifications for matching keys
+	channel := fmt.Sprintf("__keyspace@%d__:", db.config.Database)
+	c := db.pool.Get()
+	psc := redis.PubSubConn{Conn: c}
+	err := psc.PSubscribe(channel + escapePattern(keyOrPrefix) + "*")
+	if err != nil {
+		c.Close()
+		close(ch)
+		return ch, func() {}
+	}
+
+	done := make(chan struct{})
+	var once sync.Once
+	stop := func() {
+		once.Do(func() {
+			close(done)
+		})
+	}
+
+	// Unsubscribe once stopped or the database is closed, causing the receive loop to exit
+	unsubscribed := make(chan struct{})
+	go func() {
+		defer close(unsubscribed)
+		select {
+		case <-done:
+		case <-db.closed:
+		}
+		_ = psc.PUnsubscribe()
+	}()
+
+	go func() {
+		defer func() {
+			// Wait for the unsubscribe to finish before releasing the connection
+			stop()
+			<-unsubscribed
+			c.Close()
+			close(ch)
+		}()
+
+		for {
+			switch m := psc.ReceiveWithTimeout(0).(type) {
+			case error:
+				return
+			case redis.Subscription:
+				if m.Count == 0 {
+					return
+				}
+			case redis.Message:
+				e := hord.Event{Key: strings.TrimPrefix(m.Channel, channel)}
+				switch string(m.Data) {
+				case "set":
+					d, err := db.Get(e.Key)
+					if err != nil {
+						continue
+					}
+					e.Type = hord.EventPut
+					e.Value = d
+				case "del", "expired", "evicted":
+					e.Type = hord.EventDelete
+				default:
+					continue
+				}
+
+				select {
+				case ch <- e:
+				case <-done:
+					return
+				}
+			}
+		}
+	}()
+
+	return ch, stop
+}
@@ -0,0 +1,311 @@
+/*
+Package encrypt provides a Hord database wrapper that transparently encrypts values before they are stored and decrypts
+them when read, using AES-GCM. Keys are supplied by a KeyProvider and each stored value records the ID of the key used
+to encrypt it, allowing keys to be rotated while values written under older keys remain readable. To use this package,
+import it as follows:
+
+	import (
+	    "github.com/madflojo/hord"
+	    "github.com/madflojo/hord/encrypt"
+	)
+
+# Wrapping a Database
+
+Use the Wrap() function to add encryption to an existing database.
+
+	// Handle database connection
+	var database hord.Database
+	...
+
+	keys, err := encrypt.NewKeyring("2024-01", map[string][]byte{
+		"2024-01": key, // 16, 24, or 32 bytes
+	})
+	if err != nil {
+	    // Handle key error
+	}
+
+	db, err := encrypt.Wrap(database, encrypt.Config{Keys: keys})
+	if err != nil {
+	    // Handle configuration error
+	}
+
+# Key Rotation
+
+New values are always encrypted with the KeyProvider's current key. Rotating to a new key only requires the previous
+keys to remain available from the KeyProvider.
+
+	err := keys.Rotate("2024-06", newKey)
+	if err != nil {
+	    // Handle key error
+	}
+
+# Value Format
+
+Each stored value is made up of a version byte, a single byte key ID length, the key ID, a random nonce, and the
+sealed data. Values are bound to the key they are stored under, a value copied to a different key will fail to
+decrypt.
+
+The Interceptor() function returns encryption as a hord.Interceptor, allowing it to be combined with other
+interceptors using hord.Wrap(). Compression must run before encryption, as encrypted values do not compress.
+*/
+package encrypt
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/madflojo/hord"
+)
+
+// version is the format version of encrypted values.
+const version byte = 1
+
+var (
+	// ErrNoKeyProvider is returned when Wrap or Interceptor are called without a KeyProvider.
+	ErrNoKeyProvider = errors.New("KeyProvider cannot be nil")
+
+	// ErrUnknownKey is returned when a key ID is not known to the KeyProvider.
+	ErrUnknownKey = errors.New("unknown encryption key")
+
+	// ErrInvalidKeyID is returned when a key ID is empty or longer than 255 bytes.
+	ErrInvalidKeyID = errors.New("key ID must be between 1 and 255 bytes")
+
+	// ErrInvalidValue is returned when a stored value is not a valid encrypted value.
+	ErrInvalidValue = errors.New("value is not encrypted or is corrupt")
+)
+
+// KeyProvider supplies the keys used to encrypt and decrypt values. Keys must be 16, 24, or 32 bytes, selecting
+// AES-128, AES-192, or AES-256. The key returned for an ID must never change.
+type KeyProvider interface {
+	// CurrentKey returns the ID and key used to encrypt new values.
+	CurrentKey() (id string, key []byte, err error)
+
+	// Key returns the key for the provided ID, used to decrypt values. ErrUnknownKey should be returned for unknown
+	// IDs.
+	Key(id string) ([]byte, error)
+}
+
+// Config provides the configuration options for the encryption wrapper.
+type Config struct {
+	// Keys supplies the encryption keys.
+	Keys KeyProvider
+}
+
+// Wrap returns a Database that encrypts values stored within db.
+func Wrap(db hord.Database, cfg Config) (hord.Database, error) {
+	ic, err := Interceptor(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return hord.Wrap(db, ic), nil
+}
+
+// Interceptor returns a hord.Interceptor that encrypts values on Set and SetWithTTL operations and decrypts values
+// returned from Get operations.
+func Interceptor(cfg Config) (hord.Interceptor, error) {
+	if cfg.Keys == nil {
+		return nil, ErrNoKeyProvider
+	}
+
+	s := &sealer{keys: cfg.Keys, aeads: make(map[string]cipher.AEAD)}
+	return func(ctx context.Context, call hord.Call, next hord.Handler) (hord.Result, error) {
+		switch call.Op {
+		case hord.OpSet, hord.OpSetWithTTL:
+			if err := hord.ValidData(call.Value); err != nil {
+				return hord.Result{}, err
+			}
+			v, err := s.seal(call.Key, call.Value)
+			if err != nil {
+				return hord.Result{}, err
+			}
+			call.Value = v
+			return next(ctx, call)
+		case hord.OpGet:
+			r, err := next(ctx, call)
+			if err != nil {
+				return r, err
+			}
+			r.Value, err = s.open(call.Key, r.Value)
+			return r, err
+		default:
+			return next(ctx, call)
+		}
+	}, nil
+}
+
+// sealer encrypts and decrypts values.
+type sealer struct {
+	sync.RWMutex
+
+	// keys supplies encryption keys
+	keys KeyProvider
+
+	// aeads caches ciphers by key ID
+	aeads map[string]cipher.AEAD
+}
+
+// aead returns the cipher for the key ID, creating it from key if needed.
+func (s *sealer) aead(id string, key []byte) (cipher.AEAD, error) {
+	s.RLock()
+	a, ok := s.aeads[id]
+	s.RUnlock()
+	if ok {
+		return a, nil
+	}
+
+	if key == nil {
+		var err error
+		key, err = s.keys.Key(id)
+		if err != nil {
+			return nil, fmt.Errorf("unable to fetch key %q - %w", id, err)
+		}
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key %q - %w", id, err)
+	}
+	a, err = cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key %q - %w", id, err)
+	}
+
+	s.Lock()
+	s.aeads[id] = a
+	s.Unlock()
+	return a, nil
+}
+
+// seal encrypts data with the current key, binding it to the storage key.
+func (s *sealer) seal(key string, data []byte) ([]byte, error) {
+	id, k, err := s.keys.CurrentKey()
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch current key - %w", err)
+	}
+	if len(id) == 0 || len(id) > 255 {
+		return nil, ErrInvalidKeyID
+	}
+
+	a, err := s.aead(id, k)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, 0, 2+len(id)+a.NonceSize()+len(data)+a.Overhead())
+	out = append(out, version, byte(len(id)))
+	out = append(out, id...)
+
+	nonce := make([]byte, a.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("unable to generate nonce - %w", err)
+	}
+	out = append(out, nonce...)
+
+	return a.Seal(out, nonce, data, []byte(key)), nil
+}
+
+// open decrypts data, using the key ID recorded within the value.
+func (s *sealer) open(key string, data []byte) ([]byte, error) {
+	if len(data) < 2 || data[0] != version || len(data) < 2+int(data[1]) {
+		return nil, ErrInvalidValue
+	}
+
+	n := 2 + int(data[1])
+	id := string(data[2:n])
+	a, err := s.aead(id, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) < n+a.NonceSize()+a.Overhead() {
+		return nil, ErrInvalidValue
+	}
+	nonce := data[n : n+a.NonceSize()]
+
+	out, err := a.Open(nil, nonce, data[n+a.NonceSize():], []byte(key))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidValue, err)
+	}
+	return out, nil
+}
+
+// Keyring is an in-memory KeyProvider supporting key rotation.
+type Keyring struct {
+	sync.RWMutex
+
+	// current is the ID of the key used for new values
+	current string
+
+	// keys holds all known keys by ID
+	keys map[string][]byte
+}
+
+// NewKeyring returns a Keyring holding the provided keys, using the key with ID current to encrypt new values.
+func NewKeyring(current string, keys map[string][]byte) (*Keyring, error) {
+	kr := &Keyring{keys: make(map[string][]byte, len(keys))}
+	for id, key := range keys {
+		if err := validKey(id, key); err != nil {
+			return nil, err
+		}
+		kr.keys[id] = append([]byte{}, key...)
+	}
+
+	if _, ok := kr.keys[current]; !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownKey, current)
+	}
+	kr.current = current
+	return kr, nil
+}
+
+// CurrentKey returns the ID and key used to encrypt new values.
+func (kr *Keyring) CurrentKey() (string, []byte, error) {
+	kr.RLock()
+	defer kr.RUnlock()
+	return kr.current, kr.keys[kr.current], nil
+}
+
+// Key returns the key for the provided ID.
+func (kr *Keyring) Key(id string) ([]byte, error) {
+	kr.RLock()
+	defer kr.RUnlock()
+	key, ok := kr.keys[id]
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+	return key, nil
+}
+
+// Rotate adds the key to the Keyring and makes it the current key. Previous keys remain available for decryption.
+// Rotating to an existing ID with a different key is not allowed.
+func (kr *Keyring) Rotate(id string, key []byte) error {
+	if err := validKey(id, key); err != nil {
+		return err
+	}
+
+	kr.Lock()
+	defer kr.Unlock()
+	if existing, ok := kr.keys[id]; ok && string(existing) != string(key) {
+		return fmt.Errorf("key %q already exists with a different value", id)
+	}
+	kr.keys[id] = append([]byte{}, key...)
+	kr.current = id
+	return nil
+}
+
+// validKey checks the key ID and key size.
+func validKey(id string, key []byte) error {
+	if len(id) == 0 || len(id) > 255 {
+		return ErrInvalidKeyID
+	}
+	switch len(key) {
+	case 16, 24, 32:
+		return nil
+	default:
+		return fmt.Errorf("invalid key %q - must be 16, 24, or 32 bytes", id)
+	}
+}
@@ -0,0 +1,203 @@
+package encrypt
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/madflojo/hord"
+	"github.com/madflojo/hord/compress"
+	"github.com/madflojo/hord/drivers/hashmap"
+)
+
+func TestEncrypt(t *testing.T) {
+	store, err := hashmap.Dial(hashmap.Config{})
+	if err != nil {
+		t.Fatalf("Unexpected error creating hashmap - %s", err)
+	}
+	defer store.Close()
+
+	keys, err := NewKeyring("k1", map[string][]byte{"k1": bytes.Repeat([]byte{1}, 32)})
+	if err != nil {
+		t.Fatalf("Unexpected error creating keyring - %s", err)
+	}
+
+	db, err := Wrap(store, Config{Keys: keys})
+	if err != nil {
+		t.Fatalf("Unexpected error wrapping database - %s", err)
+	}
+
+	secret := []byte("123-45-6789")
+
+	t.Run("Round Trip", func(t *testing.T) {
+		if err := db.Set("ssn", secret); err != nil {
+			t.Fatalf("Unexpected error - %s", err)
+		}
+
+		stored, err := store.Get("ssn")
+		if err != nil {
+			t.Fatalf("Unexpected error - %s", err)
+		}
+		if bytes.Contains(stored, secret) {
+			t.Errorf("Expected value to be encrypted at rest")
+		}
+		if stored[0] != version || string(stored[2:2+stored[1]]) != "k1" {
+			t.Errorf("Expected key ID header, got %v", stored[:4])
+		}
+
+		data, err := db.Get("ssn")
+		if err != nil || !bytes.Equal(data, secret) {
+			t.Errorf("Unexpected value %q - %v", data, err)
+		}
+	})
+
+	t.Run("Unique Nonce", func(t *testing.T) {
+		if err := db.Set("a", secret); err != nil {
+			t.Fatalf("Unexpected error - %s", err)
+		}
+		if err := db.Set("b", secret); err != nil {
+			t.Fatalf("Unexpected error - %s", err)
+		}
+		a, _ := store.Get("a")
+		b, _ := store.Get("b")
+		if bytes.Equal(a, b) {
+			t.Errorf("Expected identical values to encrypt differently")
+		}
+	})
+
+	t.Run("Rotation", func(t *testing.T) {
+		if err := keys.Rotate("k2", bytes.Repeat([]byte{2}, 16)); err != nil {
+			t.Fatalf("Unexpected error rotating key - %s", err)
+		}
+		if err := db.(hord.TTLDatabase).SetWithTTL("new", secret, time.Minute); err != nil {
+			t.Fatalf("Unexpected error - %s", err)
+		}
+
+		stored, _ := store.Get("new")
+		if string(stored[2:2+stored[1]]) != "k2" {
+			t.Errorf("Expected new value to use rotated key")
+		}
+
+		for _, k := range []string{"ssn", "new"} {
+			data, err := db.Get(k)
+			if err != nil || !bytes.Equal(data, secret) {
+				t.Errorf("Unexpected value for %s %q - %v", k, data, err)
+			}
+		}
+
+		if err := keys.Rotate("k1", bytes.Repeat([]byte{3}, 32)); err == nil {
+			t.Errorf("Expected error rotating to an existing ID with a different key")
+		}
+	})
+
+	t.Run("Moved Value", func(t *testing.T) {
+		stored, _ := store.Get("ssn")
+		if err := store.Set("copy", stored); err != nil {
+			t.Fatalf("Unexpected error - %s", err)
+		}
+		if _, err := db.Get("copy"); !errors.Is(err, ErrInvalidValue) {
+			t.Errorf("Expected ErrInvalidValue, got %v", err)
+		}
+	})
+
+	t.Run("Invalid Values", func(t *testing.T) {
+		values := map[string][]byte{
+			"plaintext": []byte("plaintext"),
+			"truncated": {version, 2, 'k', '1', 0, 1},
+		}
+		for k, v := range values {
+			if err := store.Set(k, v); err != nil {
+				t.Fatalf("Unexpected error - %s", err)
+			}
+			if _, err := db.Get(k); !errors.Is(err, ErrInvalidValue) {
+				t.Errorf("Expected ErrInvalidValue for %s, got %v", k, err)
+			}
+		}
+
+		if err := store.Set("unknown", []byte{version, 2, 'k', '9', 0, 1, 2}); err != nil {
+			t.Fatalf("Unexpected error - %s", err)
+		}
+		if _, err := db.Get("unknown"); !errors.Is(err, ErrUnknownKey) {
+			t.Errorf("Expected ErrUnknownKey, got %v", err)
+		}
+
+		if _, err := db.Get("missing"); err != hord.ErrNil {
+			t.Errorf("Expected ErrNil, got %v", err)
+		}
+		if err := db.Set("empty", nil); err != hord.ErrInvalidData {
+			t.Errorf("Expected ErrInvalidData, got %v", err)
+		}
+	})
+}
+
+func TestWithCompression(t *testing.T) {
+	store, err := hashmap.Dial(hashmap.Config{})
+	if err != nil {
+		t.Fatalf("Unexpected error creating hashmap - %s", err)
+	}
+	defer store.Close()
+
+	keys, err := NewKeyring("k1", map[string][]byte{"k1": bytes.Repeat([]byte{1}, 32)})
+	if err != nil {
+		t.Fatalf("Unexpected error creating keyring - %s", err)
+	}
+
+	zip, err := compress.Interceptor(compress.Config{Threshold: 1})
+	if err != nil {
+		t.Fatalf("Unexpected error - %s", err)
+	}
+	seal, err := Interceptor(Config{Keys: keys})
+	if err != nil {
+		t.Fatalf("Unexpected error - %s", err)
+	}
+	db := hord.Wrap(store, zip, seal)
+
+	value := bytes.Repeat([]byte("hord "), 500)
+	if err := db.Set("key", value); err != nil {
+		t.Fatalf("Unexpected error - %s", err)
+	}
+
+	stored, _ := store.Get("key")
+	if len(stored) >= len(value) {
+		t.Errorf("Expected value to be compressed before encryption, stored %d bytes", len(stored))
+	}
+
+	data, err := db.Get("key")
+	if err != nil || !bytes.Equal(data, value) {
+		t.Errorf("Unexpected value - %v", err)
+	}
+}
+
+func TestKeyring(t *testing.T) {
+	if _, err := Wrap(nil, Config{}); err != ErrNoKeyProvider {
+		t.Errorf("Expected ErrNoKeyProvider, got %v", err)
+	}
+
+	tc := map[string]struct {
+		current string
+		keys    map[string][]byte
+	}{
+		"Unknown Current": {current: "k2", keys: map[string][]byte{"k1": make([]byte, 16)}},
+		"Short Key":       {current: "k1", keys: map[string][]byte{"k1": make([]byte, 8)}},
+		"Empty ID":        {current: "", keys: map[string][]byte{"": make([]byte, 16)}},
+	}
+	for name, c := range tc {
+		t.Run(name, func(t *testing.T) {
+			if _, err := NewKeyring(c.current, c.keys); err == nil {
+				t.Errorf("Expected error creating keyring")
+			}
+		})
+	}
+
+	kr, err := NewKeyring("k1", map[string][]byte{"k1": make([]byte, 24)})
+	if err != nil {
+		t.Fatalf("Unexpected error creating keyring - %s", err)
+	}
+	if _, err := kr.Key("k2"); err != ErrUnknownKey {
+		t.Errorf("Expected ErrUnknownKey, got %v", err)
+	}
+	if err := kr.Rotate("k2", make([]byte, 7)); err == nil {
+		t.Errorf("Expected error rotating to an invalid key")
+	}
+}
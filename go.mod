@@ -5,16 +5,16 @@ go 1.20
 require (
 	github.com/FZambia/sentinel v1.1.1
 	github.com/gocql/gocql v1.6.0
+	github.com/golang/snappy v0.0.4
 	github.com/gomodule/redigo v1.9.2
+	github.com/klauspost/compress v1.17.7
 	github.com/nats-io/nats.go v1.36.0
 	go.etcd.io/bbolt v1.3.9
 	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
-	github.com/golang/snappy v0.0.4 // indirect
 	github.com/hailocab/go-hostpool v0.0.0-20160125115350-e80d13ce29ed // indirect
-	github.com/klauspost/compress v1.17.7 // indirect
 	github.com/nats-io/nkeys v0.4.7 // indirect
 	github.com/nats-io/nuid v1.0.1 // indirect
 	golang.org/x/crypto v0.20.0 // indirect
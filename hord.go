@@ -53,17 +53,38 @@ Once you have a database client, you can use it to perform various database oper
 
 Refer to the `hord.Database` interface documentation for a complete list of available methods.
 
+# Context Support
+
+Drivers also implement the `hord.ContextDatabase` interface, which provides context-aware versions of each method. Deadlines and cancellations are passed through to the underlying database calls.
+
+	value, err := db.GetContext(ctx, "key")
+	if err != nil {
+	    // Handle error
+	}
+
+Use `hord.WrapContext()` to adapt any `hord.Database` into a `hord.ContextDatabase`.
+
 # Error Handling
 
 Hord provides common error types and constants for consistent error handling across drivers. Refer to the `hord` package documentation for more information on error handling.
 
+Drivers map the native errors of their underlying client onto these errors while keeping the original error in the chain, allowing failures to be classified uniformly with errors.Is.
+
+	err := db.Set("key", []byte("value"))
+	if errors.Is(err, hord.ErrTimeout) || errors.Is(err, hord.ErrUnavailable) {
+	    // Retry the operation
+	}
+
 # Contributing
 
 Contributions to Hord are welcome! If you want to add support for a new database driver or improve the existing codebase, please refer to the contribution guidelines in the project's repository.
 */
 package hord
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 // Database is an interface that is used to create a unified database access object.
 type Database interface {
@@ -105,6 +126,15 @@ var (
 	ErrNoDial          = fmt.Errorf("No database connection defined, did you dial?")
 	ErrInvalidDatabase = fmt.Errorf("Database cannot be nil")
 	ErrCacheError      = fmt.Errorf("Cache error")
+	ErrInvalidTTL      = fmt.Errorf("TTL must be greater than zero")
+	ErrNotSupported    = fmt.Errorf("Operation not supported by database driver")
+	ErrConflict        = fmt.Errorf("Version conflict, key was modified")
+	ErrInvalidVersion  = fmt.Errorf("Version cannot be empty")
+	ErrKeyExists       = fmt.Errorf("Key already exists")
+	ErrTimeout         = fmt.Errorf("Operation timed out")
+	ErrUnavailable     = fmt.Errorf("Database is unavailable")
+	ErrClosed          = fmt.Errorf("Database connection is closed")
+	ErrValueTooLarge   = fmt.Errorf("Value exceeds the maximum size supported by the database")
 )
 
 // ValidKey checks if a key is valid.
@@ -126,3 +156,13 @@ func ValidData(data []byte) error {
 	}
 	return ErrInvalidData
 }
+
+// ValidTTL checks if a TTL is valid.
+// A valid TTL should be greater than 0.
+// Returns nil if the TTL is valid, otherwise returns ErrInvalidTTL.
+func ValidTTL(ttl time.Duration) error {
+	if ttl > 0 {
+		return nil
+	}
+	return ErrInvalidTTL
+}
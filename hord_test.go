@@ -2,6 +2,7 @@ package hord
 
 import (
 	"testing"
+	"time"
 )
 
 // TestValidations brought to you buy ChatGPT
@@ -50,3 +51,37 @@ func TestValidations(t *testing.T) {
 		}
 	})
 }
+
+func TestValidTTL(t *testing.T) {
+	t.Run("ValidTTL", func(t *testing.T) {
+		validTTLs := []time.Duration{time.Nanosecond, time.Second, time.Hour}
+		for _, ttl := range validTTLs {
+			err := ValidTTL(ttl)
+			if err != nil {
+				t.Errorf("ValidTTL(%s) returned error: %s, expected nil", ttl, err)
+			}
+		}
+	})
+
+	t.Run("InvalidTTL", func(t *testing.T) {
+		invalidTTLs := []time.Duration{0, -time.Second}
+		for _, ttl := range invalidTTLs {
+			err := ValidTTL(ttl)
+			if err != ErrInvalidTTL {
+				t.Errorf("ValidTTL(%s) returned error: %s, expected ErrInvalidTTL", ttl, err)
+			}
+		}
+	})
+}
+
+func TestValidVersion(t *testing.T) {
+	if err := ValidVersion(Version("1")); err != nil {
+		t.Errorf("ValidVersion returned error: %s, expected nil", err)
+	}
+
+	for _, v := range []Version{nil, {}} {
+		if err := ValidVersion(v); err != ErrInvalidVersion {
+			t.Errorf("ValidVersion(%v) returned error: %s, expected ErrInvalidVersion", v, err)
+		}
+	}
+}
@@ -0,0 +1,220 @@
+package hord
+
+import (
+	"context"
+	"time"
+)
+
+// Op identifies the Database method being called within an interceptor chain.
+type Op string
+
+const (
+	OpSetup       Op = "Setup"
+	OpHealthCheck Op = "HealthCheck"
+	OpGet         Op = "Get"
+	OpSet         Op = "Set"
+	OpSetWithTTL  Op = "SetWithTTL"
+	OpDelete      Op = "Delete"
+	OpKeys        Op = "Keys"
+)
+
+// Call describes a single operation passing through an interceptor chain. Calls are passed by value, an interceptor
+// can modify its copy (for example, rewriting Key) before passing it to the next Handler without affecting the Call
+// seen by other interceptors.
+type Call struct {
+	// Op is the Database method being called.
+	Op Op
+
+	// Key is the key for Get, Set, SetWithTTL, and Delete operations.
+	Key string
+
+	// Value is the data being written for Set and SetWithTTL operations.
+	Value []byte
+
+	// TTL is the expiration for SetWithTTL operations.
+	TTL time.Duration
+}
+
+// Result holds the data returned by an operation.
+type Result struct {
+	// Value is the data returned by Get operations.
+	Value []byte
+
+	// Keys is the list of keys returned by Keys operations.
+	Keys []string
+}
+
+// Handler executes a Call, returning its Result.
+type Handler func(ctx context.Context, call Call) (Result, error)
+
+// Interceptor is called for every operation performed through a Database returned by Wrap. Interceptors can inspect or
+// modify the Call, invoke next zero or more times, and inspect or modify the returned Result and error.
+//
+//	logger := func(ctx context.Context, call hord.Call, next hord.Handler) (hord.Result, error) {
+//	    r, err := next(ctx, call)
+//	    log.Printf("%s %q - %v", call.Op, call.Key, err)
+//	    return r, err
+//	}
+type Interceptor func(ctx context.Context, call Call, next Handler) (Result, error)
+
+// Chain combines multiple interceptors into a single Interceptor. Interceptors are called in the order provided, the
+// first Interceptor is the outermost.
+func Chain(interceptors ...Interceptor) Interceptor {
+	return func(ctx context.Context, call Call, next Handler) (Result, error) {
+		return chain(interceptors, next)(ctx, call)
+	}
+}
+
+// chain builds a Handler that runs each interceptor in order before calling h.
+func chain(interceptors []Interceptor, h Handler) Handler {
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		ic, next := interceptors[i], h
+		h = func(ctx context.Context, call Call) (Result, error) {
+			return ic(ctx, call, next)
+		}
+	}
+	return h
+}
+
+// Wrap returns a Database that passes every operation through the provided interceptors before calling db, allowing
+// cross-cutting behaviors such as logging, authorization, or key rewriting to be composed. Interceptors are called in
+// the order provided, the first Interceptor is the outermost.
+//
+//	db = hord.Wrap(db, logger, auth)
+//
+// The returned Database implements ContextDatabase and TTLDatabase. If db does not support TTLs, SetWithTTL returns
+// ErrNotSupported. Close is passed directly to db.
+func Wrap(db Database, interceptors ...Interceptor) Database {
+	w := &wrapped{db: db}
+	w.handler = chain(interceptors, w.call)
+	return w
+}
+
+// wrapped is a Database that runs operations through an interceptor chain.
+type wrapped struct {
+	// db is the wrapped Database
+	db Database
+
+	// handler is the interceptor chain, ending with call
+	handler Handler
+}
+
+// call executes the Call against the wrapped Database. It is the final Handler within the interceptor chain.
+func (w *wrapped) call(ctx context.Context, call Call) (Result, error) {
+	if w.db == nil {
+		return Result{}, ErrNoDial
+	}
+	db := WrapContext(w.db)
+
+	var r Result
+	var err error
+	switch call.Op {
+	case OpSetup:
+		err = db.SetupContext(ctx)
+	case OpHealthCheck:
+		err = db.HealthCheckContext(ctx)
+	case OpGet:
+		r.Value, err = db.GetContext(ctx, call.Key)
+	case OpSet:
+		err = db.SetContext(ctx, call.Key, call.Value)
+	case OpSetWithTTL:
+		tdb, ok := w.db.(TTLDatabase)
+		if !ok {
+			return r, ErrNotSupported
+		}
+		if err = ctx.Err(); err == nil {
+			err = tdb.SetWithTTL(call.Key, call.Value, call.TTL)
+		}
+	case OpDelete:
+		err = db.DeleteContext(ctx, call.Key)
+	case OpKeys:
+		r.Keys, err = db.KeysContext(ctx)
+	default:
+		err = ErrNotSupported
+	}
+	return r, err
+}
+
+// Setup runs Setup through the interceptor chain.
+func (w *wrapped) Setup() error {
+	return w.SetupContext(context.Background())
+}
+
+// SetupContext is a context-aware version of Setup.
+func (w *wrapped) SetupContext(ctx context.Context) error {
+	_, err := w.handler(ctx, Call{Op: OpSetup})
+	return err
+}
+
+// HealthCheck runs HealthCheck through the interceptor chain.
+func (w *wrapped) HealthCheck() error {
+	return w.HealthCheckContext(context.Background())
+}
+
+// HealthCheckContext is a context-aware version of HealthCheck.
+func (w *wrapped) HealthCheckContext(ctx context.Context) error {
+	_, err := w.handler(ctx, Call{Op: OpHealthCheck})
+	return err
+}
+
+// Get runs Get through the interceptor chain.
+func (w *wrapped) Get(key string) ([]byte, error) {
+	return w.GetContext(context.Background(), key)
+}
+
+// GetContext is a context-aware version of Get.
+func (w *wrapped) GetContext(ctx context.Context, key string) ([]byte, error) {
+	r, err := w.handler(ctx, Call{Op: OpGet, Key: key})
+	return r.Value, err
+}
+
+// Set runs Set through the interceptor chain.
+func (w *wrapped) Set(key string, data []byte) error {
+	return w.SetContext(context.Background(), key, data)
+}
+
+// SetContext is a context-aware version of Set.
+func (w *wrapped) SetContext(ctx context.Context, key string, data []byte) error {
+	_, err := w.handler(ctx, Call{Op: OpSet, Key: key, Value: data})
+	return err
+}
+
+// SetWithTTL runs SetWithTTL through the interceptor chain.
+func (w *wrapped) SetWithTTL(key string, data []byte, ttl time.Duration) error {
+	_, err := w.handler(context.Background(), Call{Op: OpSetWithTTL, Key: key, Value: data, TTL: ttl})
+	return err
+}
+
+// Delete runs Delete through the interceptor chain.
+func (w *wrapped) Delete(key string) error {
+	return w.DeleteContext(context.Background(), key)
+}
+
+// DeleteContext is a context-aware version of Delete.
+func (w *wrapped) DeleteContext(ctx context.Context, key string) error {
+	_, err := w.handler(ctx, Call{Op: OpDelete, Key: key})
+	return err
+}
+
+// Keys runs Keys through the interceptor chain.
+func (w *wrapped) Keys() ([]string, error) {
+	return w.KeysContext(context.Background())
+}
+
+// KeysContext is a context-aware version of Keys.
+func (w *wrapped) KeysContext(ctx context.Context) ([]string, error) {
+	r, err := w.handler(ctx, Call{Op: OpKeys})
+	return r.Keys, err
+}
+
+// Capabilities reports Context support along with the TTL support of the wrapped Database.
+func (w *wrapped) Capabilities() Capabilities {
+	return Capabilities{Context: true, TTL: CapabilitiesOf(w.db).TTL}
+}
+
+// Close closes the wrapped Database.
+func (w *wrapped) Close() {
+	if w.db != nil {
+		w.db.Close()
+	}
+}
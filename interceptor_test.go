@@ -0,0 +1,156 @@
+package hord
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWrap(t *testing.T) {
+	var order []string
+	record := func(name string) Interceptor {
+		return func(ctx context.Context, call Call, next Handler) (Result, error) {
+			order = append(order, name+":"+string(call.Op))
+			return next(ctx, call)
+		}
+	}
+
+	prefix := func(ctx context.Context, call Call, next Handler) (Result, error) {
+		if call.Key != "" {
+			call.Key = "tenant:" + call.Key
+		}
+		r, err := next(ctx, call)
+		for i, k := range r.Keys {
+			r.Keys[i] = strings.TrimPrefix(k, "tenant:")
+		}
+		return r, err
+	}
+
+	m := &mapDatabase{data: make(map[string][]byte)}
+	db := Wrap(m, record("a"), record("b"), prefix)
+
+	t.Run("Order", func(t *testing.T) {
+		order = nil
+		if err := db.Setup(); err != nil {
+			t.Fatalf("Unexpected error - %s", err)
+		}
+		if err := db.HealthCheck(); err != nil {
+			t.Fatalf("Unexpected error - %s", err)
+		}
+
+		expected := []string{"a:Setup", "b:Setup", "a:HealthCheck", "b:HealthCheck"}
+		if !reflect.DeepEqual(order, expected) {
+			t.Errorf("Unexpected interceptor order %v, expected %v", order, expected)
+		}
+	})
+
+	t.Run("Key Rewriting", func(t *testing.T) {
+		if err := db.Set("key", []byte("data")); err != nil {
+			t.Fatalf("Unexpected error - %s", err)
+		}
+		if _, ok := m.data["tenant:key"]; !ok {
+			t.Errorf("Expected key to be rewritten, got %v", m.data)
+		}
+
+		data, err := db.Get("key")
+		if err != nil || string(data) != "data" {
+			t.Errorf("Unexpected Get result %q - %v", data, err)
+		}
+
+		keys, err := db.Keys()
+		if err != nil || !reflect.DeepEqual(keys, []string{"key"}) {
+			t.Errorf("Unexpected Keys result %v - %v", keys, err)
+		}
+
+		if err := db.Delete("key"); err != nil {
+			t.Fatalf("Unexpected error - %s", err)
+		}
+		if len(m.data) != 0 {
+			t.Errorf("Expected key to be deleted, got %v", m.data)
+		}
+	})
+
+	t.Run("Context", func(t *testing.T) {
+		cdb, ok := db.(ContextDatabase)
+		if !ok {
+			t.Fatalf("Expected wrapped database to implement ContextDatabase")
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if _, err := cdb.GetContext(ctx, "key"); !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+	})
+
+	t.Run("TTL Not Supported", func(t *testing.T) {
+		tdb, ok := db.(TTLDatabase)
+		if !ok {
+			t.Fatalf("Expected wrapped database to implement TTLDatabase")
+		}
+		if err := tdb.SetWithTTL("key", []byte("data"), time.Minute); !errors.Is(err, ErrNotSupported) {
+			t.Errorf("Expected ErrNotSupported, got %v", err)
+		}
+		if c := CapabilitiesOf(db); !c.Context || c.TTL {
+			t.Errorf("Unexpected capabilities %+v", c)
+		}
+	})
+
+	t.Run("Nil Database", func(t *testing.T) {
+		if err := Wrap(nil).Set("key", []byte("data")); err != ErrNoDial {
+			t.Errorf("Expected ErrNoDial, got %v", err)
+		}
+	})
+}
+
+func TestInterceptorShortCircuit(t *testing.T) {
+	deny := func(ctx context.Context, call Call, next Handler) (Result, error) {
+		if call.Op == OpDelete {
+			return Result{}, errTest
+		}
+		return next(ctx, call)
+	}
+
+	m := &mapDatabase{data: map[string][]byte{"key": []byte("data")}}
+	db := Wrap(m, deny)
+
+	if err := db.Delete("key"); err != errTest {
+		t.Errorf("Expected interceptor error, got %v", err)
+	}
+	if _, ok := m.data["key"]; !ok {
+		t.Errorf("Expected Delete to be blocked by interceptor")
+	}
+}
+
+func TestChain(t *testing.T) {
+	var order []string
+	record := func(name string) Interceptor {
+		return func(ctx context.Context, call Call, next Handler) (Result, error) {
+			order = append(order, name)
+			r, err := next(ctx, call)
+			order = append(order, "/"+name)
+			return r, err
+		}
+	}
+
+	m := &mapDatabase{data: map[string][]byte{"b": nil, "a": nil}}
+	db := Wrap(m, record("a"), Chain(record("b"), record("c")), record("d"))
+
+	keys, err := db.Keys()
+	if err != nil {
+		t.Fatalf("Unexpected error - %s", err)
+	}
+	sort.Strings(keys)
+	if !reflect.DeepEqual(keys, []string{"a", "b"}) {
+		t.Errorf("Unexpected keys %v", keys)
+	}
+
+	expected := []string{"a", "b", "c", "d", "/d", "/c", "/b", "/a"}
+	if !reflect.DeepEqual(order, expected) {
+		t.Errorf("Unexpected interceptor order %v, expected %v", order, expected)
+	}
+}
@@ -0,0 +1,102 @@
+package hord
+
+// Iterator is used to walk through keys stored within a database without loading the entire keyspace into memory.
+//
+//	iter, err := hord.IterateKeys(db)
+//	if err != nil {
+//	    // Handle error
+//	}
+//	defer iter.Close()
+//
+//	for iter.Next() {
+//	    key := iter.Key()
+//	    // Use key
+//	}
+//	if err := iter.Err(); err != nil {
+//	    // Handle error
+//	}
+type Iterator interface {
+	// Next advances the iterator to the next key. Next returns false when there are no more keys or an error
+	// occurred; use Err to distinguish between the two.
+	Next() bool
+
+	// Key returns the current key. Key is only valid after Next has returned true.
+	Key() string
+
+	// Err returns any error encountered while iterating.
+	Err() error
+
+	// Close releases any resources held by the iterator. Close is safe to call multiple times.
+	Close() error
+}
+
+// IterableDatabase is an optional interface implemented by drivers that support streaming key iteration. Use the
+// IterateKeys function to iterate over any Database; it will use the native implementation when available and fall
+// back to iterating over the results of Keys otherwise.
+type IterableDatabase interface {
+	Database
+
+	// IterateKeys returns an Iterator that walks all keys stored within the database. Depending on the driver, keys
+	// added or removed while iterating may or may not be returned.
+	IterateKeys() (Iterator, error)
+}
+
+// IterateKeys returns an Iterator for the provided Database. If the Database implements IterableDatabase, the native
+// implementation is used; otherwise, the keys returned from Keys are iterated.
+func IterateKeys(db Database) (Iterator, error) {
+	if db == nil {
+		return nil, ErrInvalidDatabase
+	}
+
+	if idb, ok := db.(IterableDatabase); ok {
+		return idb.IterateKeys()
+	}
+
+	keys, err := db.Keys()
+	if err != nil {
+		return nil, err
+	}
+	return NewSliceIterator(keys), nil
+}
+
+// NewSliceIterator returns an Iterator over the provided keys. It is useful for drivers that already hold a
+// snapshot of their keys in memory.
+func NewSliceIterator(keys []string) Iterator {
+	return &sliceIterator{keys: keys, pos: -1}
+}
+
+// sliceIterator is an Iterator backed by a slice of keys.
+type sliceIterator struct {
+	keys []string
+	pos  int
+}
+
+// Next advances to the next key within the slice.
+func (i *sliceIterator) Next() bool {
+	if i.pos+1 >= len(i.keys) {
+		i.pos = len(i.keys)
+		return false
+	}
+	i.pos++
+	return true
+}
+
+// Key returns the current key.
+func (i *sliceIterator) Key() string {
+	if i.pos < 0 || i.pos >= len(i.keys) {
+		return ""
+	}
+	return i.keys[i.pos]
+}
+
+// Err always returns nil as iterating a slice cannot fail.
+func (i *sliceIterator) Err() error {
+	return nil
+}
+
+// Close releases the underlying slice.
+func (i *sliceIterator) Close() error {
+	i.keys = nil
+	i.pos = 0
+	return nil
+}
@@ -0,0 +1,89 @@
+package hord
+
+import (
+	"testing"
+)
+
+func TestSliceIterator(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		iter := NewSliceIterator(nil)
+		if iter.Next() {
+			t.Errorf("Expected Next to return false for an empty iterator")
+		}
+		if iter.Key() != "" {
+			t.Errorf("Expected empty key, got %s", iter.Key())
+		}
+		if err := iter.Close(); err != nil {
+			t.Errorf("Close returned error: %s", err)
+		}
+	})
+
+	t.Run("Keys", func(t *testing.T) {
+		keys := []string{"a", "b", "c"}
+		iter := NewSliceIterator(keys)
+		defer iter.Close()
+
+		var got []string
+		for iter.Next() {
+			got = append(got, iter.Key())
+		}
+		if iter.Err() != nil {
+			t.Errorf("Err returned error: %s", iter.Err())
+		}
+		if len(got) != len(keys) {
+			t.Fatalf("Expected %d keys, got %d", len(keys), len(got))
+		}
+		for i := range keys {
+			if got[i] != keys[i] {
+				t.Errorf("Expected key %s, got %s", keys[i], got[i])
+			}
+		}
+		if iter.Next() {
+			t.Errorf("Expected Next to return false after the last key")
+		}
+	})
+
+	t.Run("Closed", func(t *testing.T) {
+		iter := NewSliceIterator([]string{"a"})
+		_ = iter.Close()
+		if iter.Next() {
+			t.Errorf("Expected Next to return false after Close")
+		}
+	})
+}
+
+func TestIterateKeys(t *testing.T) {
+	t.Run("Nil Database", func(t *testing.T) {
+		_, err := IterateKeys(nil)
+		if err != ErrInvalidDatabase {
+			t.Errorf("IterateKeys returned error: %s, expected ErrInvalidDatabase", err)
+		}
+	})
+
+	t.Run("Keys Error", func(t *testing.T) {
+		_, err := IterateKeys(&errDatabase{})
+		if err != errTest {
+			t.Errorf("IterateKeys returned error: %s, expected %s", err, errTest)
+		}
+	})
+
+	t.Run("Fallback", func(t *testing.T) {
+		db := &mapDatabase{data: map[string][]byte{"a": []byte("1"), "b": []byte("2")}}
+		iter, err := IterateKeys(db)
+		if err != nil {
+			t.Fatalf("IterateKeys returned error: %s", err)
+		}
+		defer iter.Close()
+
+		count := 0
+		for iter.Next() {
+			if _, ok := db.data[iter.Key()]; !ok {
+				t.Errorf("Unexpected key returned: %s", iter.Key())
+			}
+			count++
+		}
+		if count != 2 {
+			t.Errorf("Expected 2 keys, got %d", count)
+		}
+	})
+}
@@ -0,0 +1,228 @@
+/*
+Package limiter provides a Hord database wrapper that limits the rate and concurrency of operations made against a
+database. Reads, writes, and Keys() calls each have their own budget, preventing bulk workloads from saturating a
+database shared with latency sensitive traffic. To use this package, import it as follows:
+
+	import (
+	    "github.com/madflojo/hord"
+	    "github.com/madflojo/hord/limiter"
+	)
+
+# Wrapping a Database
+
+Use the Wrap() function to add limits to an existing database.
+
+	// Handle database connection
+	var database hord.Database
+	...
+
+	db := limiter.Wrap(database, limiter.Config{
+		Reads:  limiter.Limit{Rate: 1000, Concurrency: 50},
+		Writes: limiter.Limit{Rate: 200, Concurrency: 10},
+		Keys:   limiter.Limit{Rate: 0.1, Concurrency: 1},
+	})
+
+By default, operations exceeding a limit wait until they are allowed or their context is done. Set FailFast to return
+ErrLimited instead.
+
+The Interceptor() function returns the limits as a hord.Interceptor, allowing them to be combined with other
+interceptors using hord.Wrap().
+*/
+package limiter
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/madflojo/hord"
+)
+
+// ErrLimited is returned when FailFast is set and an operation exceeds its limit.
+var ErrLimited = errors.New("Operation limit exceeded")
+
+// Limit defines the budget for a class of operations. A zero value Limit is unlimited.
+type Limit struct {
+	// Rate is the number of operations allowed per second. A Rate of 0 disables rate limiting.
+	Rate float64
+
+	// Burst is the number of operations allowed to exceed Rate momentarily. Defaults to Rate rounded up, with a
+	// minimum of 1.
+	Burst int
+
+	// Concurrency is the number of operations allowed in-flight at once. A Concurrency of 0 disables concurrency
+	// limiting.
+	Concurrency int
+}
+
+// Config provides the configuration options for the limiter wrapper.
+type Config struct {
+	// Reads limits Get operations.
+	Reads Limit
+
+	// Writes limits Set, SetWithTTL, and Delete operations.
+	Writes Limit
+
+	// Keys limits Keys operations.
+	Keys Limit
+
+	// FailFast returns ErrLimited when an operation exceeds its limit rather than waiting.
+	FailFast bool
+}
+
+// Wrap returns a Database that limits the operations made against db.
+func Wrap(db hord.Database, cfg Config) hord.Database {
+	return hord.Wrap(db, Interceptor(cfg))
+}
+
+// Interceptor returns a hord.Interceptor that limits operations. Setup and HealthCheck operations are not limited.
+func Interceptor(cfg Config) hord.Interceptor {
+	reads := newLimiter(cfg.Reads)
+	writes := newLimiter(cfg.Writes)
+	keys := newLimiter(cfg.Keys)
+
+	return func(ctx context.Context, call hord.Call, next hord.Handler) (hord.Result, error) {
+		var l *limiter
+		switch call.Op {
+		case hord.OpGet:
+			l = reads
+		case hord.OpSet, hord.OpSetWithTTL, hord.OpDelete:
+			l = writes
+		case hord.OpKeys:
+			l = keys
+		default:
+			return next(ctx, call)
+		}
+
+		release, err := l.acquire(ctx, !cfg.FailFast)
+		if err != nil {
+			return hord.Result{}, err
+		}
+		defer release()
+		return next(ctx, call)
+	}
+}
+
+// limiter enforces a single Limit.
+type limiter struct {
+	// bucket enforces the rate limit, nil when unlimited
+	bucket *bucket
+
+	// sem enforces the concurrency limit, nil when unlimited
+	sem chan struct{}
+}
+
+// newLimiter creates a limiter for the provided Limit.
+func newLimiter(l Limit) *limiter {
+	lim := &limiter{}
+	if l.Rate > 0 {
+		burst := float64(l.Burst)
+		if burst <= 0 {
+			burst = math.Max(1, math.Ceil(l.Rate))
+		}
+		lim.bucket = &bucket{rate: l.Rate, burst: burst, tokens: burst, last: time.Now()}
+	}
+	if l.Concurrency > 0 {
+		lim.sem = make(chan struct{}, l.Concurrency)
+	}
+	return lim
+}
+
+// acquire waits for the operation to be allowed, returning a function that must be called once the operation
+// completes. If block is false, ErrLimited is returned rather than waiting.
+func (l *limiter) acquire(ctx context.Context, block bool) (func(), error) {
+	if l.bucket != nil {
+		wait, ok := l.bucket.reserve(block)
+		if !ok {
+			return nil, ErrLimited
+		}
+		if wait > 0 {
+			t := time.NewTimer(wait)
+			select {
+			case <-ctx.Done():
+				t.Stop()
+				l.bucket.cancel()
+				return nil, ctx.Err()
+			case <-t.C:
+			}
+		}
+	}
+
+	if l.sem == nil {
+		return func() {}, nil
+	}
+
+	// Return the token if the operation is rejected waiting for a concurrency slot
+	if !block {
+		select {
+		case l.sem <- struct{}{}:
+		default:
+			l.release()
+			return nil, ErrLimited
+		}
+	} else {
+		select {
+		case l.sem <- struct{}{}:
+		case <-ctx.Done():
+			l.release()
+			return nil, ctx.Err()
+		}
+	}
+	return func() { <-l.sem }, nil
+}
+
+// release returns a rate limit token taken by an operation that did not run.
+func (l *limiter) release() {
+	if l.bucket != nil {
+		l.bucket.cancel()
+	}
+}
+
+// bucket is a token bucket rate limiter.
+type bucket struct {
+	sync.Mutex
+
+	// rate is the number of tokens added per second
+	rate float64
+
+	// burst is the maximum number of tokens held
+	burst float64
+
+	// tokens is the number of available tokens, negative values represent reservations waiting for tokens
+	tokens float64
+
+	// last is the time tokens were last added
+	last time.Time
+}
+
+// reserve takes a token, returning how long to wait before the token is available. If block is false and no token is
+// available, reserve returns false without taking a token.
+func (b *bucket) reserve(block bool) (time.Duration, bool) {
+	b.Lock()
+	defer b.Unlock()
+
+	now := time.Now()
+	b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0, true
+	}
+	if !block {
+		return 0, false
+	}
+
+	wait := time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+	b.tokens--
+	return wait, true
+}
+
+// cancel returns a reserved token that was not used.
+func (b *bucket) cancel() {
+	b.Lock()
+	defer b.Unlock()
+	b.tokens = math.Min(b.burst, b.tokens+1)
+}
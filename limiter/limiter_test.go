@@ -0,0 +1,214 @@
+package limiter
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/madflojo/hord"
+	"github.com/madflojo/hord/drivers/mock"
+)
+
+func TestRateLimit(t *testing.T) {
+	db, err := mock.Dial(mock.Config{})
+	if err != nil {
+		t.Fatalf("Unexpected error creating mock database - %s", err)
+	}
+
+	t.Run("Blocking", func(t *testing.T) {
+		ldb := Wrap(db, Config{Writes: Limit{Rate: 100, Burst: 1}})
+
+		start := time.Now()
+		for i := 0; i < 5; i++ {
+			if err := ldb.Set("key", []byte("data")); err != nil {
+				t.Fatalf("Unexpected error - %s", err)
+			}
+		}
+		if elapsed := time.Since(start); elapsed < 35*time.Millisecond {
+			t.Errorf("Expected writes to be rate limited, took %s", elapsed)
+		}
+
+		// Reads have a separate budget
+		start = time.Now()
+		for i := 0; i < 5; i++ {
+			if _, err := ldb.Get("key"); err != nil && err != hord.ErrNil {
+				t.Fatalf("Unexpected error - %s", err)
+			}
+		}
+		if elapsed := time.Since(start); elapsed > 30*time.Millisecond {
+			t.Errorf("Expected reads not to be rate limited, took %s", elapsed)
+		}
+	})
+
+	t.Run("Fail Fast", func(t *testing.T) {
+		ldb := Wrap(db, Config{Keys: Limit{Rate: 1, Burst: 2}, FailFast: true})
+
+		for i := 0; i < 2; i++ {
+			if _, err := ldb.Keys(); err != nil {
+				t.Fatalf("Unexpected error - %s", err)
+			}
+		}
+		if _, err := ldb.Keys(); err != ErrLimited {
+			t.Errorf("Expected ErrLimited, got %v", err)
+		}
+	})
+
+	t.Run("Context", func(t *testing.T) {
+		ldb := Wrap(db, Config{Reads: Limit{Rate: 0.1}}).(hord.ContextDatabase)
+		if _, err := ldb.Get("key"); err != nil && err != hord.ErrNil {
+			t.Fatalf("Unexpected error - %s", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		if _, err := ldb.GetContext(ctx, "key"); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+		}
+	})
+
+	t.Run("Not Limited", func(t *testing.T) {
+		ldb := Wrap(db, Config{Reads: Limit{Rate: 0.1, Burst: 1}, FailFast: true})
+		for i := 0; i < 3; i++ {
+			if err := ldb.HealthCheck(); err != nil {
+				t.Fatalf("Unexpected error - %s", err)
+			}
+		}
+	})
+}
+
+func TestConcurrencyLimit(t *testing.T) {
+	var inflight, peak int32
+	release := make(chan struct{})
+	db, err := mock.Dial(mock.Config{
+		GetFunc: func(_ string) ([]byte, error) {
+			n := atomic.AddInt32(&inflight, 1)
+			defer atomic.AddInt32(&inflight, -1)
+			for {
+				p := atomic.LoadInt32(&peak)
+				if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+					break
+				}
+			}
+			<-release
+			return []byte("data"), nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error creating mock database - %s", err)
+	}
+
+	ldb := Wrap(db, Config{Reads: Limit{Concurrency: 2}})
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := ldb.Get("key"); err != nil {
+				t.Errorf("Unexpected error - %s", err)
+			}
+		}()
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	if n := atomic.LoadInt32(&inflight); n != 2 {
+		t.Errorf("Expected 2 in-flight operations, got %d", n)
+	}
+
+	t.Run("Fail Fast", func(t *testing.T) {
+		fdb := Wrap(db, Config{Reads: Limit{Concurrency: 1}, FailFast: true})
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			_, _ = fdb.Get("key")
+		}()
+
+		time.Sleep(10 * time.Millisecond)
+		if _, err := fdb.Get("key"); err != ErrLimited {
+			t.Errorf("Expected ErrLimited, got %v", err)
+		}
+		close(release)
+		<-done
+	})
+
+	wg.Wait()
+	if p := atomic.LoadInt32(&peak); p > 3 {
+		t.Errorf("Expected at most 3 in-flight operations, got %d", p)
+	}
+}
+
+func TestRateAndConcurrencyLimit(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{}, 1)
+	db, err := mock.Dial(mock.Config{
+		GetFunc: func(key string) ([]byte, error) {
+			if key == "slow" {
+				started <- struct{}{}
+				<-release
+			}
+			return []byte("data"), nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error creating mock database - %s", err)
+	}
+
+	tc := map[string]struct {
+		failFast bool
+		reject   func(ldb hord.ContextDatabase) error
+		expected error
+	}{
+		"Fail Fast": {
+			failFast: true,
+			reject: func(ldb hord.ContextDatabase) error {
+				_, err := ldb.Get("key")
+				return err
+			},
+			expected: ErrLimited,
+		},
+		"Blocking": {
+			reject: func(ldb hord.ContextDatabase) error {
+				ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+				defer cancel()
+				_, err := ldb.GetContext(ctx, "key")
+				return err
+			},
+			expected: context.DeadlineExceeded,
+		},
+	}
+
+	for name, c := range tc {
+		t.Run(name, func(t *testing.T) {
+			ldb := Wrap(db, Config{Reads: Limit{Rate: 0.1, Burst: 3, Concurrency: 1}, FailFast: c.failFast}).(hord.ContextDatabase)
+
+			// Hold the only concurrency slot, using one token
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				_, _ = ldb.Get("slow")
+			}()
+			<-started
+
+			// Rejected operations must not use the remaining tokens
+			for i := 0; i < 5; i++ {
+				if err := c.reject(ldb); !errors.Is(err, c.expected) {
+					t.Fatalf("Expected %v, got %v", c.expected, err)
+				}
+			}
+
+			release <- struct{}{}
+			<-done
+
+			for i := 0; i < 2; i++ {
+				ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+				_, err := ldb.GetContext(ctx, "key")
+				cancel()
+				if err != nil {
+					t.Errorf("Expected remaining tokens to be available, got %v", err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,292 @@
+/*
+Package middleware provides a Hord database wrapper that reports the latency, cache hits and misses, and errors of each
+operation to a pluggable Observer. Observers bridge these notifications to metrics and tracing systems, such as
+Prometheus or OpenTelemetry, without Hord depending on them directly. To use this package, import it as follows:
+
+	import (
+	    "github.com/madflojo/hord"
+	    "github.com/madflojo/hord/middleware"
+	)
+
+# Wrapping a Database
+
+Use the Dial() function to wrap an existing database with an Observer.
+
+	// Handle database connection
+	var database hord.Database
+	...
+
+	var db hord.Database
+	db, err := middleware.Dial(middleware.Config{
+		Database: database,
+		Name:     "redis",
+		Observer: observer,
+	})
+	if err != nil {
+	    // Handle connection error
+	}
+
+# Observing Operations
+
+Each call made against the wrapped database is reported to the Observer. Start is called before the operation begins,
+and the returned context is passed to the wrapped database, allowing trace spans to be propagated. Finish is called
+once the operation completes with a Result containing the operation's duration, error, error class, and for Get
+operations, whether the key was found.
+
+	func (m *metrics) Finish(ctx context.Context, op middleware.Operation, r middleware.Result) {
+	    m.latency.WithLabelValues(op.Database, op.Name).Observe(r.Duration.Seconds())
+	    if r.Hit {
+	        m.hits.WithLabelValues(op.Database).Inc()
+	    }
+	    if r.Miss {
+	        m.misses.WithLabelValues(op.Database).Inc()
+	    }
+	    if r.Class != middleware.ClassNone {
+	        m.errors.WithLabelValues(op.Database, op.Name, string(r.Class)).Inc()
+	    }
+	}
+
+Use MultiObserver() to report operations to more than one Observer.
+*/
+package middleware
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/madflojo/hord"
+)
+
+// Config provides the configuration options for the middleware wrapper.
+type Config struct {
+	// Database is the database being observed.
+	Database hord.Database
+
+	// Observer is notified of each operation performed against Database.
+	Observer Observer
+
+	// Name is an optional name for Database, passed to the Observer within each Operation. Name is used to label
+	// metrics and spans by driver when multiple databases are observed.
+	Name string
+}
+
+// Database wraps a hord.Database, reporting each operation to an Observer. It also satisfies the Hord database
+// interface.
+type Database struct {
+	// database is the wrapped database as provided within Config
+	database hord.Database
+
+	// db runs operations against database through the observing interceptor
+	db hord.ContextDatabase
+
+	// observer is notified of each operation
+	observer Observer
+
+	// name is passed to observer within each Operation
+	name string
+}
+
+var (
+	// ErrNoObserver is returned when Dial is called without an Observer.
+	ErrNoObserver = errors.New("Observer cannot be nil")
+)
+
+// Dial will wrap the Database within the provided Config. It will return an error if either the Database or Observer
+// values in Config are nil.
+func Dial(cfg Config) (*Database, error) {
+	if cfg.Database == nil {
+		return nil, hord.ErrInvalidDatabase
+	}
+
+	if cfg.Observer == nil {
+		return nil, ErrNoObserver
+	}
+
+	db := &Database{
+		database: cfg.Database,
+		observer: cfg.Observer,
+		name:     cfg.Name,
+	}
+	db.db = hord.Wrap(cfg.Database, db.intercept).(hord.ContextDatabase)
+	return db, nil
+}
+
+// intercept reports each operation passing through the interceptor chain to the Observer.
+func (db *Database) intercept(ctx context.Context, call hord.Call, next hord.Handler) (hord.Result, error) {
+	var r hord.Result
+	err := db.observe(ctx, string(call.Op), call.Key, func(ctx context.Context) error {
+		var err error
+		r, err = next(ctx, call)
+		return err
+	})
+	return r, err
+}
+
+// observe reports the execution of fn to the Observer.
+func (db *Database) observe(ctx context.Context, name, key string, fn func(ctx context.Context) error) error {
+	op := Operation{Name: name, Database: db.name, Key: key}
+	ctx = db.observer.Start(ctx, op)
+
+	start := time.Now()
+	err := fn(ctx)
+	r := Result{
+		Duration: time.Since(start),
+		Err:      err,
+		Class:    Classify(err),
+	}
+	if name == string(hord.OpGet) {
+		r.Hit = err == nil
+		r.Miss = errors.Is(err, hord.ErrNil)
+	}
+
+	db.observer.Finish(ctx, op, r)
+	return err
+}
+
+// Setup will run the Setup function of the wrapped database.
+func (db *Database) Setup() error {
+	return db.SetupContext(context.Background())
+}
+
+// SetupContext is a context-aware version of Setup.
+func (db *Database) SetupContext(ctx context.Context) error {
+	if db == nil || db.db == nil {
+		return hord.ErrNoDial
+	}
+	return db.db.SetupContext(ctx)
+}
+
+// HealthCheck will run the HealthCheck function of the wrapped database.
+func (db *Database) HealthCheck() error {
+	return db.HealthCheckContext(context.Background())
+}
+
+// HealthCheckContext is a context-aware version of HealthCheck.
+func (db *Database) HealthCheckContext(ctx context.Context) error {
+	if db == nil || db.db == nil {
+		return hord.ErrNoDial
+	}
+	return db.db.HealthCheckContext(ctx)
+}
+
+// Get will fetch the key from the wrapped database, reporting a hit or miss to the Observer.
+func (db *Database) Get(key string) ([]byte, error) {
+	return db.GetContext(context.Background(), key)
+}
+
+// GetContext is a context-aware version of Get.
+func (db *Database) GetContext(ctx context.Context, key string) ([]byte, error) {
+	if db == nil || db.db == nil {
+		return nil, hord.ErrNoDial
+	}
+	return db.db.GetContext(ctx, key)
+}
+
+// Set will write the key to the wrapped database.
+func (db *Database) Set(key string, data []byte) error {
+	return db.SetContext(context.Background(), key, data)
+}
+
+// SetContext is a context-aware version of Set.
+func (db *Database) SetContext(ctx context.Context, key string, data []byte) error {
+	if db == nil || db.db == nil {
+		return hord.ErrNoDial
+	}
+	return db.db.SetContext(ctx, key, data)
+}
+
+// SetWithTTL will write the key with an expiration to the wrapped database. If the wrapped database does not support
+// TTLs, hord.ErrNotSupported is returned.
+func (db *Database) SetWithTTL(key string, data []byte, ttl time.Duration) error {
+	if db == nil || db.db == nil {
+		return hord.ErrNoDial
+	}
+	return db.db.(hord.TTLDatabase).SetWithTTL(key, data, ttl)
+}
+
+// Delete will remove the key from the wrapped database.
+func (db *Database) Delete(key string) error {
+	return db.DeleteContext(context.Background(), key)
+}
+
+// DeleteContext is a context-aware version of Delete.
+func (db *Database) DeleteContext(ctx context.Context, key string) error {
+	if db == nil || db.db == nil {
+		return hord.ErrNoDial
+	}
+	return db.db.DeleteContext(ctx, key)
+}
+
+// Keys will return the keys of the wrapped database.
+func (db *Database) Keys() ([]string, error) {
+	return db.KeysContext(context.Background())
+}
+
+// KeysContext is a context-aware version of Keys.
+func (db *Database) KeysContext(ctx context.Context) ([]string, error) {
+	if db == nil || db.db == nil {
+		return nil, hord.ErrNoDial
+	}
+	return db.db.KeysContext(ctx)
+}
+
+// GetMany will fetch multiple keys from the wrapped database, using its native batch support when available.
+func (db *Database) GetMany(keys []string) (map[string][]byte, error) {
+	if db == nil || db.db == nil {
+		return nil, hord.ErrNoDial
+	}
+
+	var items map[string][]byte
+	err := db.observe(context.Background(), "GetMany", "", func(_ context.Context) error {
+		var err error
+		items, err = hord.GetMany(db.database, keys)
+		return err
+	})
+	return items, err
+}
+
+// SetMany will write multiple keys to the wrapped database, using its native batch support when available.
+func (db *Database) SetMany(items map[string][]byte) error {
+	if db == nil || db.db == nil {
+		return hord.ErrNoDial
+	}
+
+	return db.observe(context.Background(), "SetMany", "", func(_ context.Context) error {
+		return hord.SetMany(db.database, items)
+	})
+}
+
+// DeleteMany will remove multiple keys from the wrapped database, using its native batch support when available.
+func (db *Database) DeleteMany(keys []string) error {
+	if db == nil || db.db == nil {
+		return hord.ErrNoDial
+	}
+
+	return db.observe(context.Background(), "DeleteMany", "", func(_ context.Context) error {
+		return hord.DeleteMany(db.database, keys)
+	})
+}
+
+// GetDatabase returns the wrapped database.
+func (db *Database) GetDatabase() hord.Database {
+	return db.database
+}
+
+// Capabilities reports the optional features supported by the middleware wrapper, based on the wrapped database.
+func (db *Database) Capabilities() hord.Capabilities {
+	if db == nil || db.database == nil {
+		return hord.Capabilities{}
+	}
+
+	c := hord.CapabilitiesOf(db.database)
+	return hord.Capabilities{Context: true, TTL: c.TTL, Batch: c.Batch}
+}
+
+// Close will close the wrapped database.
+func (db *Database) Close() {
+	if db == nil || db.db == nil {
+		return
+	}
+	db.db.Close()
+}
@@ -0,0 +1,308 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/madflojo/hord"
+	"github.com/madflojo/hord/drivers/hashmap"
+	"github.com/madflojo/hord/drivers/mock"
+)
+
+// ctxKey is used to verify contexts returned from Observer.Start are passed along.
+type ctxKey struct{}
+
+// recorder is an Observer that records every finished operation.
+type recorder struct {
+	sync.Mutex
+	ops     []Operation
+	results []Result
+	spans   int
+}
+
+func (r *recorder) Start(ctx context.Context, _ Operation) context.Context {
+	r.Lock()
+	defer r.Unlock()
+	r.spans++
+	return context.WithValue(ctx, ctxKey{}, r.spans)
+}
+
+func (r *recorder) Finish(ctx context.Context, op Operation, result Result) {
+	r.Lock()
+	defer r.Unlock()
+	if ctx.Value(ctxKey{}) != r.spans {
+		result.Err = fmt.Errorf("context from Start not passed to Finish")
+	}
+	r.ops = append(r.ops, op)
+	r.results = append(r.results, result)
+}
+
+func (r *recorder) last() (Operation, Result) {
+	r.Lock()
+	defer r.Unlock()
+	return r.ops[len(r.ops)-1], r.results[len(r.results)-1]
+}
+
+func TestDial(t *testing.T) {
+	db, err := mock.Dial(mock.Config{})
+	if err != nil {
+		t.Fatalf("Unexpected error creating mock database - %s", err)
+	}
+
+	_, err = Dial(Config{Observer: &recorder{}})
+	if err != hord.ErrInvalidDatabase {
+		t.Errorf("Expected ErrInvalidDatabase, got %v", err)
+	}
+
+	_, err = Dial(Config{Database: db})
+	if err != ErrNoObserver {
+		t.Errorf("Expected ErrNoObserver, got %v", err)
+	}
+
+	var nilDB *Database
+	if err := nilDB.Set("key", []byte("data")); err != hord.ErrNoDial {
+		t.Errorf("Expected ErrNoDial, got %v", err)
+	}
+}
+
+func TestObserver(t *testing.T) {
+	errDB := errors.New("database failure")
+	db, err := mock.Dial(mock.Config{
+		GetFunc: func(key string) ([]byte, error) {
+			switch key {
+			case "hit":
+				return []byte("data"), nil
+			case "miss":
+				return nil, hord.ErrNil
+			default:
+				return nil, fmt.Errorf("unable to fetch - %w", hord.ErrTimeout)
+			}
+		},
+		SetFunc: func(_ string, _ []byte) error {
+			return errDB
+		},
+		KeysFunc: func() ([]string, error) {
+			return []string{"hit"}, nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error creating mock database - %s", err)
+	}
+
+	r := &recorder{}
+	mdb, err := Dial(Config{Database: db, Observer: r, Name: "mock"})
+	if err != nil {
+		t.Fatalf("Unexpected error wrapping database - %s", err)
+	}
+	defer mdb.Close()
+
+	if mdb.GetDatabase() != db {
+		t.Errorf("Expected GetDatabase to return the wrapped database")
+	}
+
+	tc := []struct {
+		name  string
+		call  func() error
+		op    Operation
+		class ErrorClass
+		hit   bool
+		miss  bool
+	}{
+		{
+			name: "Hit",
+			call: func() error {
+				_, err := mdb.Get("hit")
+				return err
+			},
+			op:  Operation{Name: "Get", Database: "mock", Key: "hit"},
+			hit: true,
+		},
+		{
+			name: "Miss",
+			call: func() error {
+				_, err := mdb.GetContext(context.Background(), "miss")
+				if err != hord.ErrNil {
+					return fmt.Errorf("expected ErrNil, got %v", err)
+				}
+				return nil
+			},
+			op:   Operation{Name: "Get", Database: "mock", Key: "miss"},
+			miss: true,
+		},
+		{
+			name: "Timeout",
+			call: func() error {
+				_, err := mdb.Get("slow")
+				if !errors.Is(err, hord.ErrTimeout) {
+					return fmt.Errorf("expected ErrTimeout, got %v", err)
+				}
+				return nil
+			},
+			op:    Operation{Name: "Get", Database: "mock", Key: "slow"},
+			class: ClassTimeout,
+		},
+		{
+			name: "Set Error",
+			call: func() error {
+				if err := mdb.Set("key", []byte("data")); err != errDB {
+					return fmt.Errorf("expected database error, got %v", err)
+				}
+				return nil
+			},
+			op:    Operation{Name: "Set", Database: "mock", Key: "key"},
+			class: ClassUnknown,
+		},
+		{
+			name: "Delete",
+			call: func() error { return mdb.Delete("key") },
+			op:   Operation{Name: "Delete", Database: "mock", Key: "key"},
+		},
+		{
+			name: "Keys",
+			call: func() error {
+				keys, err := mdb.Keys()
+				if len(keys) != 1 {
+					return fmt.Errorf("unexpected keys %v", keys)
+				}
+				return err
+			},
+			op: Operation{Name: "Keys", Database: "mock"},
+		},
+		{
+			name: "Setup",
+			call: mdb.Setup,
+			op:   Operation{Name: "Setup", Database: "mock"},
+		},
+		{
+			name: "HealthCheck",
+			call: mdb.HealthCheck,
+			op:   Operation{Name: "HealthCheck", Database: "mock"},
+		},
+	}
+
+	for _, c := range tc {
+		t.Run(c.name, func(t *testing.T) {
+			if err := c.call(); err != nil {
+				t.Fatalf("Unexpected error - %s", err)
+			}
+
+			op, result := r.last()
+			if op != c.op {
+				t.Errorf("Unexpected operation %+v, expected %+v", op, c.op)
+			}
+			if result.Class != c.class || result.Hit != c.hit || result.Miss != c.miss {
+				t.Errorf("Unexpected result %+v", result)
+			}
+			if result.Class == ClassNone && result.Err != nil && !result.Miss {
+				t.Errorf("Unexpected error within result - %s", result.Err)
+			}
+		})
+	}
+}
+
+func TestMultiObserver(t *testing.T) {
+	db, err := mock.Dial(mock.Config{})
+	if err != nil {
+		t.Fatalf("Unexpected error creating mock database - %s", err)
+	}
+
+	a, b := &recorder{}, &recorder{}
+	mdb, err := Dial(Config{Database: db, Observer: MultiObserver(a, b)})
+	if err != nil {
+		t.Fatalf("Unexpected error wrapping database - %s", err)
+	}
+
+	if err := mdb.Set("key", []byte("data")); err != nil {
+		t.Fatalf("Unexpected error - %s", err)
+	}
+
+	if len(a.ops) != 1 || len(b.ops) != 1 {
+		t.Errorf("Expected both observers to be notified, got %d and %d", len(a.ops), len(b.ops))
+	}
+}
+
+func TestClassify(t *testing.T) {
+	tc := map[error]ErrorClass{
+		nil:                      ClassNone,
+		hord.ErrNil:              ClassNone,
+		hord.ErrTimeout:          ClassTimeout,
+		context.DeadlineExceeded: ClassTimeout,
+		context.Canceled:         ClassCanceled,
+		hord.ErrUnavailable:      ClassUnavailable,
+		hord.ErrClosed:           ClassClosed,
+		hord.ErrNoDial:           ClassClosed,
+		hord.ErrConflict:         ClassConflict,
+		hord.ErrKeyExists:        ClassConflict,
+		hord.ErrNotSupported:     ClassNotSupported,
+		hord.ErrValueTooLarge:    ClassValueTooLarge,
+		hord.ErrInvalidKey:       ClassInvalid,
+		errors.New("unexpected"): ClassUnknown,
+	}
+
+	for err, class := range tc {
+		if c := Classify(err); c != class {
+			t.Errorf("Unexpected class for %v - got %q, expected %q", err, c, class)
+		}
+		if err != nil {
+			if c := Classify(fmt.Errorf("wrapped - %w", err)); c != class {
+				t.Errorf("Unexpected class for wrapped %v - got %q, expected %q", err, c, class)
+			}
+		}
+	}
+}
+
+func TestOptionalInterfaces(t *testing.T) {
+	db, err := hashmap.Dial(hashmap.Config{})
+	if err != nil {
+		t.Fatalf("Unexpected error creating hashmap database - %s", err)
+	}
+
+	r := &recorder{}
+	mdb, err := Dial(Config{Database: db, Observer: r})
+	if err != nil {
+		t.Fatalf("Unexpected error wrapping database - %s", err)
+	}
+	defer mdb.Close()
+
+	c := mdb.Capabilities()
+	if !c.Context || !c.TTL || !c.Batch {
+		t.Errorf("Expected capabilities of the wrapped database, got %+v", c)
+	}
+
+	t.Run("SetWithTTL", func(t *testing.T) {
+		if err := mdb.SetWithTTL("ttl", []byte("data"), time.Minute); err != nil {
+			t.Fatalf("Unexpected error - %s", err)
+		}
+		if op, _ := r.last(); op.Name != "SetWithTTL" || op.Key != "ttl" {
+			t.Errorf("Unexpected operation %+v", op)
+		}
+	})
+
+	t.Run("Batch", func(t *testing.T) {
+		if err := hord.SetMany(mdb, map[string][]byte{"a": []byte("1"), "b": []byte("2")}); err != nil {
+			t.Fatalf("Unexpected error - %s", err)
+		}
+		if op, _ := r.last(); op.Name != "SetMany" {
+			t.Errorf("Unexpected operation %+v", op)
+		}
+
+		items, err := hord.GetMany(mdb, []string{"a", "b", "c"})
+		if err != nil || len(items) != 2 {
+			t.Fatalf("Unexpected result %v - %v", items, err)
+		}
+		if op, _ := r.last(); op.Name != "GetMany" {
+			t.Errorf("Unexpected operation %+v", op)
+		}
+
+		if err := hord.DeleteMany(mdb, []string{"a", "b"}); err != nil {
+			t.Fatalf("Unexpected error - %s", err)
+		}
+		if op, _ := r.last(); op.Name != "DeleteMany" {
+			t.Errorf("Unexpected operation %+v", op)
+		}
+	})
+}
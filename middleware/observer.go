@@ -0,0 +1,136 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/madflojo/hord"
+)
+
+// Operation describes a single call made against the wrapped Database.
+type Operation struct {
+	// Name is the name of the method called, such as "Get" or "Set", matching hord.Op for single key operations.
+	// Context-aware methods share the name of their legacy counterparts.
+	Name string
+
+	// Database is the name given to the wrapped Database within Config, allowing observers to label metrics and spans
+	// by driver.
+	Database string
+
+	// Key is the key the operation was performed on. Key is empty for operations that do not take a single key, such
+	// as Setup, HealthCheck, Keys, and batch operations.
+	Key string
+}
+
+// Result describes the outcome of an Operation.
+type Result struct {
+	// Duration is the time taken by the wrapped Database to complete the operation.
+	Duration time.Duration
+
+	// Err is the error returned by the wrapped Database, if any.
+	Err error
+
+	// Class is the classification of Err. Class is ClassNone when the operation succeeded or was a cache miss.
+	Class ErrorClass
+
+	// Hit is set when a Get operation returned data.
+	Hit bool
+
+	// Miss is set when a Get operation returned hord.ErrNil.
+	Miss bool
+}
+
+// Observer receives notifications for each operation performed against the wrapped Database. Implementations are
+// used to bridge operations to metrics and tracing systems such as Prometheus or OpenTelemetry and must be safe for
+// concurrent use.
+//
+//	type tracer struct{}
+//
+//	func (t *tracer) Start(ctx context.Context, op middleware.Operation) context.Context {
+//	    ctx, _ = otel.Tracer("hord").Start(ctx, op.Name)
+//	    return ctx
+//	}
+//
+//	func (t *tracer) Finish(ctx context.Context, op middleware.Operation, r middleware.Result) {
+//	    span := trace.SpanFromContext(ctx)
+//	    if r.Class != middleware.ClassNone {
+//	        span.RecordError(r.Err)
+//	    }
+//	    span.End()
+//	}
+type Observer interface {
+	// Start is called before the operation is executed. The returned context is passed to the wrapped Database and to
+	// Finish, allowing observers to start trace spans.
+	Start(ctx context.Context, op Operation) context.Context
+
+	// Finish is called once the operation completes.
+	Finish(ctx context.Context, op Operation, result Result)
+}
+
+// MultiObserver returns an Observer that notifies each of the provided observers in order.
+func MultiObserver(observers ...Observer) Observer {
+	return multiObserver(observers)
+}
+
+// multiObserver notifies a list of observers.
+type multiObserver []Observer
+
+// Start calls Start on each observer, passing along the returned context.
+func (m multiObserver) Start(ctx context.Context, op Operation) context.Context {
+	for _, o := range m {
+		ctx = o.Start(ctx, op)
+	}
+	return ctx
+}
+
+// Finish calls Finish on each observer.
+func (m multiObserver) Finish(ctx context.Context, op Operation, result Result) {
+	for _, o := range m {
+		o.Finish(ctx, op, result)
+	}
+}
+
+// ErrorClass groups errors returned from a Database into a small set of classes suitable for use as metric labels.
+type ErrorClass string
+
+const (
+	ClassNone          ErrorClass = ""
+	ClassTimeout       ErrorClass = "timeout"
+	ClassCanceled      ErrorClass = "canceled"
+	ClassUnavailable   ErrorClass = "unavailable"
+	ClassClosed        ErrorClass = "closed"
+	ClassConflict      ErrorClass = "conflict"
+	ClassNotSupported  ErrorClass = "not_supported"
+	ClassValueTooLarge ErrorClass = "value_too_large"
+	ClassInvalid       ErrorClass = "invalid"
+	ClassUnknown       ErrorClass = "unknown"
+)
+
+// Classify returns the ErrorClass of the provided error. A nil error or hord.ErrNil returns ClassNone, as a missing key
+// is not considered a failure.
+func Classify(err error) ErrorClass {
+	switch {
+	case err == nil, errors.Is(err, hord.ErrNil):
+		return ClassNone
+	case errors.Is(err, hord.ErrTimeout), errors.Is(err, context.DeadlineExceeded):
+		return ClassTimeout
+	case errors.Is(err, context.Canceled):
+		return ClassCanceled
+	case errors.Is(err, hord.ErrUnavailable):
+		return ClassUnavailable
+	case errors.Is(err, hord.ErrClosed), errors.Is(err, hord.ErrNoDial):
+		return ClassClosed
+	case errors.Is(err, hord.ErrConflict), errors.Is(err, hord.ErrKeyExists):
+		return ClassConflict
+	case errors.Is(err, hord.ErrNotSupported):
+		return ClassNotSupported
+	case errors.Is(err, hord.ErrValueTooLarge):
+		return ClassValueTooLarge
+	case errors.Is(err, hord.ErrInvalidKey), errors.Is(err, hord.ErrInvalidData), errors.Is(err, hord.ErrInvalidTTL),
+		errors.Is(err, hord.ErrInvalidVersion):
+		return ClassInvalid
+	default:
+		return ClassUnknown
+	}
+}
@@ -0,0 +1,288 @@
+/*
+Package namespace provides a Hord database driver that isolates keys within a shared database by transparently
+prefixing them. Keys are prefixed on writes and reads, and Keys() only returns keys within the namespace with the
+prefix removed, preventing tenants sharing a database from seeing each other's data. To use this driver, import it as
+follows:
+
+	import (
+	    "github.com/madflojo/hord"
+	    "github.com/madflojo/hord/namespace"
+	)
+
+# Wrapping a Database
+
+Use the Wrap() function to create a namespace within an existing database.
+
+	// Handle database connection
+	var database hord.Database
+	...
+
+	teamA := namespace.Wrap(database, "team-a:")
+	teamB := namespace.Wrap(database, "team-b:")
+
+	// Stored as "team-a:config"
+	err := teamA.Set("config", []byte("value"))
+	if err != nil {
+	    // Handle error
+	}
+
+	// Returns hord.ErrNil
+	_, err = teamB.Get("config")
+
+Keys() uses the wrapped database's native prefix support when available, see hord.PrefixDatabase.
+*/
+package namespace
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/madflojo/hord"
+)
+
+// Database prefixes all keys with a namespace before passing them to the wrapped database. It also satisfies the Hord
+// database interface.
+type Database struct {
+	// db is the wrapped database
+	db hord.Database
+
+	// prefix is prepended to every key
+	prefix string
+}
+
+// Wrap returns a Database that stores keys within db under the provided prefix.
+func Wrap(db hord.Database, prefix string) *Database {
+	return &Database{db: db, prefix: prefix}
+}
+
+// Prefix returns the namespace prefix.
+func (db *Database) Prefix() string {
+	return db.prefix
+}
+
+// key returns the key within the wrapped database.
+func (db *Database) key(key string) string {
+	return db.prefix + key
+}
+
+// strip removes the namespace prefix from keys, omitting keys outside of the namespace.
+func (db *Database) strip(keys []string) []string {
+	results := make([]string, 0, len(keys))
+	for _, k := range keys {
+		if strings.HasPrefix(k, db.prefix) {
+			results = append(results, strings.TrimPrefix(k, db.prefix))
+		}
+	}
+	return results
+}
+
+// Setup will run the Setup function of the wrapped database.
+func (db *Database) Setup() error {
+	return db.SetupContext(context.Background())
+}
+
+// SetupContext is a context-aware version of Setup.
+func (db *Database) SetupContext(ctx context.Context) error {
+	if db == nil || db.db == nil {
+		return hord.ErrNoDial
+	}
+	return hord.WrapContext(db.db).SetupContext(ctx)
+}
+
+// HealthCheck will run the HealthCheck function of the wrapped database.
+func (db *Database) HealthCheck() error {
+	return db.HealthCheckContext(context.Background())
+}
+
+// HealthCheckContext is a context-aware version of HealthCheck.
+func (db *Database) HealthCheckContext(ctx context.Context) error {
+	if db == nil || db.db == nil {
+		return hord.ErrNoDial
+	}
+	return hord.WrapContext(db.db).HealthCheckContext(ctx)
+}
+
+// Get will fetch the key from within the namespace.
+func (db *Database) Get(key string) ([]byte, error) {
+	return db.GetContext(context.Background(), key)
+}
+
+// GetContext is a context-aware version of Get.
+func (db *Database) GetContext(ctx context.Context, key string) ([]byte, error) {
+	if db == nil || db.db == nil {
+		return nil, hord.ErrNoDial
+	}
+
+	if err := hord.ValidKey(key); err != nil {
+		return nil, err
+	}
+
+	return hord.WrapContext(db.db).GetContext(ctx, db.key(key))
+}
+
+// Set will write the key within the namespace.
+func (db *Database) Set(key string, data []byte) error {
+	return db.SetContext(context.Background(), key, data)
+}
+
+// SetContext is a context-aware version of Set.
+func (db *Database) SetContext(ctx context.Context, key string, data []byte) error {
+	if db == nil || db.db == nil {
+		return hord.ErrNoDial
+	}
+
+	if err := hord.ValidKey(key); err != nil {
+		return err
+	}
+
+	return hord.WrapContext(db.db).SetContext(ctx, db.key(key), data)
+}
+
+// SetWithTTL will write the key within the namespace with an expiration. If the wrapped database does not support
+// TTLs, hord.ErrNotSupported is returned.
+func (db *Database) SetWithTTL(key string, data []byte, ttl time.Duration) error {
+	if db == nil || db.db == nil {
+		return hord.ErrNoDial
+	}
+
+	if err := hord.ValidKey(key); err != nil {
+		return err
+	}
+
+	tdb, ok := db.db.(hord.TTLDatabase)
+	if !ok {
+		return hord.ErrNotSupported
+	}
+	return tdb.SetWithTTL(db.key(key), data, ttl)
+}
+
+// Delete will remove the key from within the namespace.
+func (db *Database) Delete(key string) error {
+	return db.DeleteContext(context.Background(), key)
+}
+
+// DeleteContext is a context-aware version of Delete.
+func (db *Database) DeleteContext(ctx context.Context, key string) error {
+	if db == nil || db.db == nil {
+		return hord.ErrNoDial
+	}
+
+	if err := hord.ValidKey(key); err != nil {
+		return err
+	}
+
+	return hord.WrapContext(db.db).DeleteContext(ctx, db.key(key))
+}
+
+// Keys will return the keys within the namespace with the prefix removed.
+func (db *Database) Keys() ([]string, error) {
+	return db.KeysContext(context.Background())
+}
+
+// KeysContext is a context-aware version of Keys. The context is only checked before fetching keys from the wrapped
+// database.
+func (db *Database) KeysContext(ctx context.Context) ([]string, error) {
+	return db.keysWithPrefix(ctx, "")
+}
+
+// KeysWithPrefix will return the keys within the namespace that start with prefix, with the namespace prefix removed.
+func (db *Database) KeysWithPrefix(prefix string) ([]string, error) {
+	return db.keysWithPrefix(context.Background(), prefix)
+}
+
+// keysWithPrefix fetches keys starting with the namespace prefix followed by prefix.
+func (db *Database) keysWithPrefix(ctx context.Context, prefix string) ([]string, error) {
+	if db == nil || db.db == nil {
+		return nil, hord.ErrNoDial
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	keys, err := hord.KeysWithPrefix(db.db, db.key(prefix))
+	if err != nil {
+		return nil, err
+	}
+	return db.strip(keys), nil
+}
+
+// GetMany will fetch multiple keys from within the namespace.
+func (db *Database) GetMany(keys []string) (map[string][]byte, error) {
+	if db == nil || db.db == nil {
+		return nil, hord.ErrNoDial
+	}
+
+	if err := hord.ValidKeys(keys); err != nil {
+		return nil, err
+	}
+
+	nkeys := make([]string, 0, len(keys))
+	for _, k := range keys {
+		nkeys = append(nkeys, db.key(k))
+	}
+
+	items, err := hord.GetMany(db.db, nkeys)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make(map[string][]byte, len(items))
+	for k, v := range items {
+		results[strings.TrimPrefix(k, db.prefix)] = v
+	}
+	return results, nil
+}
+
+// SetMany will write multiple keys within the namespace.
+func (db *Database) SetMany(items map[string][]byte) error {
+	if db == nil || db.db == nil {
+		return hord.ErrNoDial
+	}
+
+	if err := hord.ValidItems(items); err != nil {
+		return err
+	}
+
+	nitems := make(map[string][]byte, len(items))
+	for k, v := range items {
+		nitems[db.key(k)] = v
+	}
+	return hord.SetMany(db.db, nitems)
+}
+
+// DeleteMany will remove multiple keys from within the namespace.
+func (db *Database) DeleteMany(keys []string) error {
+	if db == nil || db.db == nil {
+		return hord.ErrNoDial
+	}
+
+	if err := hord.ValidKeys(keys); err != nil {
+		return err
+	}
+
+	nkeys := make([]string, 0, len(keys))
+	for _, k := range keys {
+		nkeys = append(nkeys, db.key(k))
+	}
+	return hord.DeleteMany(db.db, nkeys)
+}
+
+// Capabilities reports the optional features supported by the namespace, based on the wrapped database.
+func (db *Database) Capabilities() hord.Capabilities {
+	if db == nil || db.db == nil {
+		return hord.Capabilities{}
+	}
+
+	c := hord.CapabilitiesOf(db.db)
+	return hord.Capabilities{Context: true, TTL: c.TTL, Batch: c.Batch, Prefix: true}
+}
+
+// Close will close the wrapped database. Closing a namespace closes the database for all namespaces sharing it.
+func (db *Database) Close() {
+	if db == nil || db.db == nil {
+		return
+	}
+	db.db.Close()
+}
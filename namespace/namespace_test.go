@@ -0,0 +1,185 @@
+package namespace
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/madflojo/hord"
+	"github.com/madflojo/hord/drivers/hashmap"
+	"github.com/madflojo/hord/drivers/mock"
+)
+
+func TestNamespace(t *testing.T) {
+	shared, err := hashmap.Dial(hashmap.Config{})
+	if err != nil {
+		t.Fatalf("Unexpected error creating hashmap - %s", err)
+	}
+	defer shared.Close()
+
+	a := Wrap(shared, "team-a:")
+	b := Wrap(shared, "team-b:")
+
+	if err := a.Setup(); err != nil {
+		t.Fatalf("Unexpected error - %s", err)
+	}
+	if err := a.HealthCheck(); err != nil {
+		t.Fatalf("Unexpected error - %s", err)
+	}
+
+	t.Run("Isolation", func(t *testing.T) {
+		if err := a.Set("config", []byte("a")); err != nil {
+			t.Fatalf("Unexpected error - %s", err)
+		}
+		if err := b.Set("config", []byte("b")); err != nil {
+			t.Fatalf("Unexpected error - %s", err)
+		}
+		if err := shared.Set("global", []byte("g")); err != nil {
+			t.Fatalf("Unexpected error - %s", err)
+		}
+
+		data, err := shared.Get("team-a:config")
+		if err != nil || string(data) != "a" {
+			t.Errorf("Expected prefixed key within shared database, got %q - %v", data, err)
+		}
+
+		data, err = a.Get("config")
+		if err != nil || string(data) != "a" {
+			t.Errorf("Unexpected data %q - %v", data, err)
+		}
+
+		if _, err := a.Get("global"); err != hord.ErrNil {
+			t.Errorf("Expected ErrNil for key outside of namespace, got %v", err)
+		}
+	})
+
+	t.Run("Keys", func(t *testing.T) {
+		if err := a.Set("cfg:db", []byte("a")); err != nil {
+			t.Fatalf("Unexpected error - %s", err)
+		}
+
+		keys, err := a.Keys()
+		if err != nil {
+			t.Fatalf("Unexpected error - %s", err)
+		}
+		sort.Strings(keys)
+		if !reflect.DeepEqual(keys, []string{"cfg:db", "config"}) {
+			t.Errorf("Unexpected keys %v", keys)
+		}
+
+		keys, err = a.KeysWithPrefix("cfg:")
+		if err != nil || !reflect.DeepEqual(keys, []string{"cfg:db"}) {
+			t.Errorf("Unexpected keys %v - %v", keys, err)
+		}
+
+		keys, err = b.Keys()
+		if err != nil || !reflect.DeepEqual(keys, []string{"config"}) {
+			t.Errorf("Unexpected keys %v - %v", keys, err)
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if _, err := a.KeysContext(ctx); !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+	})
+
+	t.Run("Batch", func(t *testing.T) {
+		err := a.SetMany(map[string][]byte{"x": []byte("1"), "y": []byte("2")})
+		if err != nil {
+			t.Fatalf("Unexpected error - %s", err)
+		}
+
+		items, err := a.GetMany([]string{"x", "y", "missing"})
+		if err != nil {
+			t.Fatalf("Unexpected error - %s", err)
+		}
+		if !reflect.DeepEqual(items, map[string][]byte{"x": []byte("1"), "y": []byte("2")}) {
+			t.Errorf("Unexpected items %v", items)
+		}
+
+		items, err = b.GetMany([]string{"x", "y"})
+		if err != nil || len(items) != 0 {
+			t.Errorf("Expected no items outside of namespace, got %v - %v", items, err)
+		}
+
+		if err := a.DeleteMany([]string{"x", "y"}); err != nil {
+			t.Fatalf("Unexpected error - %s", err)
+		}
+		if _, err := shared.Get("team-a:x"); err != hord.ErrNil {
+			t.Errorf("Expected key to be deleted, got %v", err)
+		}
+	})
+
+	t.Run("TTL", func(t *testing.T) {
+		if err := a.SetWithTTL("session", []byte("s"), time.Minute); err != nil {
+			t.Fatalf("Unexpected error - %s", err)
+		}
+		if _, err := shared.Get("team-a:session"); err != nil {
+			t.Errorf("Unexpected error - %s", err)
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		if err := a.Delete("config"); err != nil {
+			t.Fatalf("Unexpected error - %s", err)
+		}
+		if _, err := b.Get("config"); err != nil {
+			t.Errorf("Expected other namespace to be unaffected, got %v", err)
+		}
+	})
+
+	t.Run("Invalid Key", func(t *testing.T) {
+		if err := a.Set("", []byte("data")); err != hord.ErrInvalidKey {
+			t.Errorf("Expected ErrInvalidKey, got %v", err)
+		}
+		if _, err := a.Get(""); err != hord.ErrInvalidKey {
+			t.Errorf("Expected ErrInvalidKey, got %v", err)
+		}
+	})
+
+	t.Run("Capabilities", func(t *testing.T) {
+		c := a.Capabilities()
+		if !c.Context || !c.TTL || !c.Batch || !c.Prefix || c.Range {
+			t.Errorf("Unexpected capabilities %+v", c)
+		}
+		if a.Prefix() != "team-a:" {
+			t.Errorf("Unexpected prefix %q", a.Prefix())
+		}
+	})
+}
+
+func TestNoTTL(t *testing.T) {
+	db, err := mock.Dial(mock.Config{})
+	if err != nil {
+		t.Fatalf("Unexpected error creating mock database - %s", err)
+	}
+
+	// Hide the mock's TTL support
+	ns := Wrap(struct{ hord.Database }{db}, "ns:")
+	if err := ns.SetWithTTL("key", []byte("data"), time.Minute); err != hord.ErrNotSupported {
+		t.Errorf("Expected ErrNotSupported, got %v", err)
+	}
+}
+
+func TestNoDial(t *testing.T) {
+	ns := Wrap(nil, "ns:")
+	if err := ns.Set("key", []byte("data")); err != hord.ErrNoDial {
+		t.Errorf("Expected ErrNoDial, got %v", err)
+	}
+	if _, err := ns.Keys(); err != hord.ErrNoDial {
+		t.Errorf("Expected ErrNoDial, got %v", err)
+	}
+	if c := ns.Capabilities(); c != (hord.Capabilities{}) {
+		t.Errorf("Expected no capabilities, got %+v", c)
+	}
+	ns.Close()
+
+	var nilNS *Database
+	if c := nilNS.Capabilities(); c != (hord.Capabilities{}) {
+		t.Errorf("Expected no capabilities, got %+v", c)
+	}
+}
@@ -0,0 +1,53 @@
+package hord
+
+import "strings"
+
+// KeyValue is a key and its associated data, used by operations that return ordered results.
+type KeyValue struct {
+	Key   string
+	Value []byte
+}
+
+// PrefixDatabase is an optional interface implemented by drivers that can natively find keys with a common prefix.
+// Use the KeysWithPrefix function to query any Database; it will use the native implementation when available and
+// fall back to filtering the results of Keys otherwise.
+type PrefixDatabase interface {
+	Database
+
+	// KeysWithPrefix will return a list of keys that start with the provided prefix.
+	KeysWithPrefix(prefix string) ([]string, error)
+}
+
+// RangeDatabase is an optional interface implemented by drivers that store keys in sorted order.
+type RangeDatabase interface {
+	Database
+
+	// Range will return the keys and data for all keys greater than or equal to start and less than end, sorted by
+	// key. An empty end will return all keys from start through the end of the database.
+	Range(start, end string) ([]KeyValue, error)
+}
+
+// KeysWithPrefix returns the keys within the provided Database that start with prefix. If the Database implements
+// PrefixDatabase, the native implementation is used; otherwise, the results of Keys are filtered client-side.
+func KeysWithPrefix(db Database, prefix string) ([]string, error) {
+	if db == nil {
+		return nil, ErrInvalidDatabase
+	}
+
+	if pdb, ok := db.(PrefixDatabase); ok {
+		return pdb.KeysWithPrefix(prefix)
+	}
+
+	keys, err := db.Keys()
+	if err != nil {
+		return nil, err
+	}
+
+	var results []string
+	for _, k := range keys {
+		if strings.HasPrefix(k, prefix) {
+			results = append(results, k)
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,37 @@
+package hord
+
+import (
+	"testing"
+)
+
+func TestKeysWithPrefix(t *testing.T) {
+	t.Run("Nil Database", func(t *testing.T) {
+		_, err := KeysWithPrefix(nil, "a")
+		if err != ErrInvalidDatabase {
+			t.Errorf("KeysWithPrefix returned error: %s, expected ErrInvalidDatabase", err)
+		}
+	})
+
+	t.Run("Keys Error", func(t *testing.T) {
+		_, err := KeysWithPrefix(&errDatabase{}, "a")
+		if err != errTest {
+			t.Errorf("KeysWithPrefix returned error: %s, expected %s", err, errTest)
+		}
+	})
+
+	t.Run("Fallback", func(t *testing.T) {
+		db := &mapDatabase{data: map[string][]byte{
+			"tenant:1:user:1": []byte("1"),
+			"tenant:1:user:2": []byte("2"),
+			"tenant:2:user:1": []byte("3"),
+		}}
+
+		keys, err := KeysWithPrefix(db, "tenant:1:")
+		if err != nil {
+			t.Fatalf("KeysWithPrefix returned error: %s", err)
+		}
+		if len(keys) != 2 {
+			t.Errorf("KeysWithPrefix returned unexpected keys: %v", keys)
+		}
+	})
+}
@@ -0,0 +1,79 @@
+package hord
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"sync"
+)
+
+// Factory creates a Database from a data source name (DSN) URL. Drivers register a Factory using Register, allowing
+// applications to open a Database using Open without importing the driver's Dial function directly.
+type Factory func(dsn string) (Database, error)
+
+var (
+	// factoriesMu guards factories
+	factoriesMu sync.RWMutex
+
+	// factories holds the registered driver factories by name
+	factories = make(map[string]Factory)
+)
+
+// Register makes a driver available by the provided name. Drivers call Register from their init function using the
+// URL scheme they support. If Register is called twice with the same name or if factory is nil, it panics.
+func Register(name string, factory Factory) {
+	factoriesMu.Lock()
+	defer factoriesMu.Unlock()
+
+	if factory == nil {
+		panic("hord: Register factory is nil")
+	}
+	if _, dup := factories[name]; dup {
+		panic("hord: Register called twice for driver " + name)
+	}
+	factories[name] = factory
+}
+
+// Drivers returns a sorted list of the names of the registered drivers.
+func Drivers() []string {
+	factoriesMu.RLock()
+	defer factoriesMu.RUnlock()
+
+	names := make([]string, 0, len(factories))
+	for name := range factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Open dials a Database using the driver registered for the scheme of the provided DSN URL. The remainder of the DSN
+// is parsed by the driver into its Config; refer to each driver's ParseDSN function for the supported format. Drivers
+// must be imported for their side effects to be registered.
+//
+//	import (
+//	    "github.com/madflojo/hord"
+//	    _ "github.com/madflojo/hord/drivers/redis"
+//	)
+//
+//	db, err := hord.Open("redis://:password@localhost:6379/2?max_active=50")
+//	if err != nil {
+//	    // Handle connection error
+//	}
+//
+// As with Dial, Setup should be called before using the returned Database.
+func Open(dsn string) (Database, error) {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse DSN - %w", err)
+	}
+
+	factoriesMu.RLock()
+	factory, ok := factories[u.Scheme]
+	factoriesMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown driver %q (forgotten import?)", u.Scheme)
+	}
+
+	return factory(dsn)
+}
@@ -0,0 +1,76 @@
+package hord
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestRegistry(t *testing.T) {
+	Register("test", func(dsn string) (Database, error) {
+		if dsn == "test://fail" {
+			return nil, fmt.Errorf("forced failure")
+		}
+		return &mapDatabase{data: map[string][]byte{}}, nil
+	})
+
+	t.Run("Drivers", func(t *testing.T) {
+		found := false
+		for _, name := range Drivers() {
+			if name == "test" {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("Expected registered driver to be listed, got %v", Drivers())
+		}
+	})
+
+	t.Run("Open", func(t *testing.T) {
+		db, err := Open("test://localhost")
+		if err != nil {
+			t.Fatalf("Unexpected error opening database: %s", err)
+		}
+		if _, ok := db.(*mapDatabase); !ok {
+			t.Errorf("Expected database from registered factory, got %T", db)
+		}
+	})
+
+	t.Run("Factory Error", func(t *testing.T) {
+		_, err := Open("test://fail")
+		if err == nil {
+			t.Errorf("Expected error from factory")
+		}
+	})
+
+	t.Run("Unknown Driver", func(t *testing.T) {
+		_, err := Open("unknown://localhost")
+		if err == nil {
+			t.Errorf("Expected error opening unknown driver")
+		}
+	})
+
+	t.Run("Invalid DSN", func(t *testing.T) {
+		_, err := Open("://")
+		if err == nil {
+			t.Errorf("Expected error opening invalid DSN")
+		}
+	})
+
+	t.Run("Duplicate Register", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("Expected panic registering duplicate driver")
+			}
+		}()
+		Register("test", func(string) (Database, error) { return nil, nil })
+	})
+
+	t.Run("Nil Factory", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("Expected panic registering nil factory")
+			}
+		}()
+		Register("nil", nil)
+	})
+}
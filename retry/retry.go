@@ -0,0 +1,175 @@
+/*
+Package retry provides a Hord database wrapper that retries failed operations with exponential backoff and jitter. To
+use this package, import it as follows:
+
+	import (
+	    "github.com/madflojo/hord"
+	    "github.com/madflojo/hord/retry"
+	)
+
+# Wrapping a Database
+
+Use the Wrap() function to add retries to an existing database.
+
+	// Handle database connection
+	var database hord.Database
+	...
+
+	db := retry.Wrap(database, retry.Config{
+		MaxAttempts:    5,
+		InitialBackoff: 50 * time.Millisecond,
+		MaxBackoff:     time.Second,
+	})
+
+By default, operations failing with hord.ErrTimeout or hord.ErrUnavailable are retried. Use the Classifier field to
+decide which errors are retried and the Policy field to decide which operations are retried.
+
+	db := retry.Wrap(database, retry.Config{
+		// Only retry reads
+		Policy: retry.ReadOnly,
+	})
+
+The Interceptor() function returns the retry behavior as a hord.Interceptor, allowing it to be combined with other
+interceptors using hord.Wrap().
+*/
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/madflojo/hord"
+)
+
+const (
+	// DefaultMaxAttempts is the number of attempts made when Config.MaxAttempts is not set.
+	DefaultMaxAttempts = 3
+
+	// DefaultInitialBackoff is the delay before the first retry when Config.InitialBackoff is not set.
+	DefaultInitialBackoff = 50 * time.Millisecond
+
+	// DefaultMaxBackoff is the maximum delay between attempts when Config.MaxBackoff is not set.
+	DefaultMaxBackoff = 2 * time.Second
+
+	// DefaultMultiplier is the backoff growth factor when Config.Multiplier is not set.
+	DefaultMultiplier = 2.0
+
+	// DefaultJitter is the fraction of each delay that is randomized when Config.Jitter is not set.
+	DefaultJitter = 0.5
+)
+
+// Config provides the configuration options for the retry wrapper. Zero values are replaced with defaults.
+type Config struct {
+	// MaxAttempts is the maximum number of times an operation is attempted, including the first attempt. Defaults to
+	// DefaultMaxAttempts.
+	MaxAttempts int
+
+	// InitialBackoff is the delay before the first retry. Defaults to DefaultInitialBackoff.
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the delay between attempts. Defaults to DefaultMaxBackoff.
+	MaxBackoff time.Duration
+
+	// Multiplier is the factor the delay grows by after each attempt. Values below 1 default to DefaultMultiplier.
+	Multiplier float64
+
+	// Jitter is the fraction, between 0 and 1, of each delay that is randomized to prevent retries from multiple
+	// clients synchronizing. A Jitter of 0.5 results in delays between 50% and 100% of the computed backoff. Defaults to
+	// DefaultJitter, set a negative value to disable jitter.
+	Jitter float64
+
+	// Policy decides if an operation may be retried. Defaults to retrying all operations, as every operation passed
+	// through hord.Wrap is idempotent.
+	Policy func(op hord.Op) bool
+
+	// Classifier decides if an error may be retried. Defaults to Transient.
+	Classifier func(err error) bool
+}
+
+// Transient returns true for errors that indicate a temporary failure, hord.ErrTimeout and hord.ErrUnavailable.
+func Transient(err error) bool {
+	return errors.Is(err, hord.ErrTimeout) || errors.Is(err, hord.ErrUnavailable)
+}
+
+// ReadOnly is a Policy that only retries operations that do not modify data.
+func ReadOnly(op hord.Op) bool {
+	switch op {
+	case hord.OpGet, hord.OpKeys, hord.OpHealthCheck:
+		return true
+	default:
+		return false
+	}
+}
+
+// Wrap returns a Database that retries failed operations against db.
+func Wrap(db hord.Database, cfg Config) hord.Database {
+	return hord.Wrap(db, Interceptor(cfg))
+}
+
+// Interceptor returns a hord.Interceptor that retries failed operations.
+func Interceptor(cfg Config) hord.Interceptor {
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = DefaultMaxAttempts
+	}
+	if cfg.InitialBackoff <= 0 {
+		cfg.InitialBackoff = DefaultInitialBackoff
+	}
+	if cfg.MaxBackoff <= 0 {
+		cfg.MaxBackoff = DefaultMaxBackoff
+	}
+	if cfg.Multiplier < 1 {
+		cfg.Multiplier = DefaultMultiplier
+	}
+	if cfg.Jitter == 0 {
+		cfg.Jitter = DefaultJitter
+	}
+	if cfg.Jitter < 0 {
+		cfg.Jitter = 0
+	}
+	if cfg.Jitter > 1 {
+		cfg.Jitter = 1
+	}
+	if cfg.Classifier == nil {
+		cfg.Classifier = Transient
+	}
+
+	return func(ctx context.Context, call hord.Call, next hord.Handler) (hord.Result, error) {
+		r, err := next(ctx, call)
+		if err == nil || (cfg.Policy != nil && !cfg.Policy(call.Op)) {
+			return r, err
+		}
+
+		backoff := cfg.InitialBackoff
+		for attempt := 1; attempt < cfg.MaxAttempts && cfg.Classifier(err); attempt++ {
+			t := time.NewTimer(cfg.delay(backoff))
+			select {
+			case <-ctx.Done():
+				t.Stop()
+				return r, fmt.Errorf("%w: %w", ctx.Err(), err)
+			case <-t.C:
+			}
+
+			r, err = next(ctx, call)
+			if err == nil {
+				return r, nil
+			}
+
+			backoff = time.Duration(float64(backoff) * cfg.Multiplier)
+			if backoff > cfg.MaxBackoff {
+				backoff = cfg.MaxBackoff
+			}
+		}
+		return r, err
+	}
+}
+
+// delay applies jitter to the provided backoff.
+func (cfg Config) delay(backoff time.Duration) time.Duration {
+	if backoff > cfg.MaxBackoff {
+		backoff = cfg.MaxBackoff
+	}
+	return backoff - time.Duration(rand.Float64()*cfg.Jitter*float64(backoff))
+}
@@ -0,0 +1,166 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/madflojo/hord"
+	"github.com/madflojo/hord/drivers/mock"
+)
+
+// flaky returns a mock database whose Get and Set calls fail with err until they have been called failures times.
+func flaky(t *testing.T, failures int32, err error) (hord.Database, *int32) {
+	var calls int32
+	fn := func() error {
+		if atomic.AddInt32(&calls, 1) <= failures {
+			return err
+		}
+		return nil
+	}
+
+	db, e := mock.Dial(mock.Config{
+		GetFunc: func(_ string) ([]byte, error) {
+			if err := fn(); err != nil {
+				return nil, err
+			}
+			return []byte("data"), nil
+		},
+		SetFunc: func(_ string, _ []byte) error {
+			return fn()
+		},
+	})
+	if e != nil {
+		t.Fatalf("Unexpected error creating mock database - %s", e)
+	}
+	return db, &calls
+}
+
+func TestRetry(t *testing.T) {
+	cfg := Config{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+	errTransient := fmt.Errorf("unable to fetch - %w", hord.ErrTimeout)
+
+	t.Run("Recovers", func(t *testing.T) {
+		db, calls := flaky(t, 2, errTransient)
+		data, err := Wrap(db, cfg).Get("key")
+		if err != nil || string(data) != "data" {
+			t.Errorf("Unexpected result %q - %v", data, err)
+		}
+		if *calls != 3 {
+			t.Errorf("Expected 3 attempts, got %d", *calls)
+		}
+	})
+
+	t.Run("Exhausted", func(t *testing.T) {
+		db, calls := flaky(t, 5, errTransient)
+		_, err := Wrap(db, cfg).Get("key")
+		if !errors.Is(err, hord.ErrTimeout) {
+			t.Errorf("Expected ErrTimeout, got %v", err)
+		}
+		if *calls != 3 {
+			t.Errorf("Expected 3 attempts, got %d", *calls)
+		}
+	})
+
+	t.Run("Not Transient", func(t *testing.T) {
+		db, calls := flaky(t, 5, hord.ErrInvalidKey)
+		_, err := Wrap(db, cfg).Get("key")
+		if err != hord.ErrInvalidKey {
+			t.Errorf("Expected ErrInvalidKey, got %v", err)
+		}
+		if *calls != 1 {
+			t.Errorf("Expected 1 attempt, got %d", *calls)
+		}
+	})
+
+	t.Run("Policy", func(t *testing.T) {
+		c := cfg
+		c.Policy = ReadOnly
+
+		db, calls := flaky(t, 5, errTransient)
+		err := Wrap(db, c).Set("key", []byte("data"))
+		if !errors.Is(err, hord.ErrTimeout) {
+			t.Errorf("Expected ErrTimeout, got %v", err)
+		}
+		if *calls != 1 {
+			t.Errorf("Expected 1 attempt, got %d", *calls)
+		}
+
+		db, calls = flaky(t, 1, errTransient)
+		_, err = Wrap(db, c).Get("key")
+		if err != nil || *calls != 2 {
+			t.Errorf("Expected Get to be retried, got %d attempts - %v", *calls, err)
+		}
+	})
+
+	t.Run("Classifier", func(t *testing.T) {
+		c := cfg
+		c.Classifier = func(err error) bool { return errors.Is(err, hord.ErrConflict) }
+
+		db, calls := flaky(t, 1, hord.ErrConflict)
+		if err := Wrap(db, c).Set("key", []byte("data")); err != nil {
+			t.Errorf("Unexpected error - %s", err)
+		}
+		if *calls != 2 {
+			t.Errorf("Expected 2 attempts, got %d", *calls)
+		}
+	})
+
+	t.Run("Context Canceled", func(t *testing.T) {
+		c := cfg
+		c.InitialBackoff = time.Minute
+		c.MaxBackoff = time.Minute
+
+		db, calls := flaky(t, 5, errTransient)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		_, err := Wrap(db, c).(hord.ContextDatabase).GetContext(ctx, "key")
+		if !errors.Is(err, context.DeadlineExceeded) || !errors.Is(err, hord.ErrTimeout) {
+			t.Errorf("Expected DeadlineExceeded wrapping ErrTimeout, got %v", err)
+		}
+		if *calls != 1 {
+			t.Errorf("Expected 1 attempt, got %d", *calls)
+		}
+	})
+}
+
+func TestDelay(t *testing.T) {
+	cfg := Config{MaxBackoff: 100 * time.Millisecond, Jitter: 0.5}
+
+	for i := 0; i < 100; i++ {
+		d := cfg.delay(80 * time.Millisecond)
+		if d < 40*time.Millisecond || d > 80*time.Millisecond {
+			t.Fatalf("Delay %s outside of jitter range", d)
+		}
+	}
+
+	if d := cfg.delay(time.Second); d > cfg.MaxBackoff {
+		t.Errorf("Delay %s exceeds MaxBackoff", d)
+	}
+
+	cfg.Jitter = 0
+	if d := cfg.delay(80 * time.Millisecond); d != 80*time.Millisecond {
+		t.Errorf("Expected delay without jitter, got %s", d)
+	}
+}
+
+func TestReadOnly(t *testing.T) {
+	tc := map[hord.Op]bool{
+		hord.OpGet:         true,
+		hord.OpKeys:        true,
+		hord.OpHealthCheck: true,
+		hord.OpSet:         false,
+		hord.OpSetWithTTL:  false,
+		hord.OpDelete:      false,
+		hord.OpSetup:       false,
+	}
+	for op, expected := range tc {
+		if ReadOnly(op) != expected {
+			t.Errorf("Unexpected ReadOnly result for %s", op)
+		}
+	}
+}
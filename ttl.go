@@ -0,0 +1,21 @@
+package hord
+
+import "time"
+
+// TTLDatabase is an optional interface implemented by drivers that support per-key expiration. Use a type assertion
+// to discover if a Database supports TTLs.
+//
+//	if ttldb, ok := db.(hord.TTLDatabase); ok {
+//	    err := ttldb.SetWithTTL("session", data, 30*time.Minute)
+//	    if err != nil {
+//	        // Handle error
+//	    }
+//	}
+type TTLDatabase interface {
+	Database
+
+	// SetWithTTL is used to insert and update the specified key with an expiration. Once the TTL has elapsed,
+	// the key is treated as deleted and Get will return ErrNil.
+	// Calling Set on a key with a TTL will remove the expiration.
+	SetWithTTL(key string, data []byte, ttl time.Duration) error
+}
@@ -0,0 +1,50 @@
+package hord
+
+// Tx provides access to keys within a transaction started by Txn. A Tx is only valid for the duration of the function
+// passed to Txn and must not be used concurrently.
+type Tx interface {
+	// Get is used to fetch data within the transaction. Data written earlier in the same transaction is returned.
+	Get(key string) ([]byte, error)
+
+	// Set is used to insert and update the specified key within the transaction.
+	Set(key string, data []byte) error
+
+	// Delete will delete the data for the specified key within the transaction.
+	Delete(key string) error
+}
+
+// TxnDatabase is an optional interface implemented by drivers that can apply multiple writes atomically. Use the Txn
+// function to start a transaction against any Database; it will return ErrNotSupported for drivers without
+// transaction support.
+//
+//	err := hord.Txn(db, func(tx hord.Tx) error {
+//	    if err := tx.Set("record:1", record); err != nil {
+//	        return err
+//	    }
+//	    return tx.Set("index:email:user@example.com", []byte("record:1"))
+//	})
+//	if err != nil {
+//	    // Handle error, no writes were applied
+//	}
+type TxnDatabase interface {
+	Database
+
+	// Txn executes fn within a transaction. If fn returns nil, the writes made through tx are committed atomically;
+	// if fn returns an error, the writes are discarded and the error is returned. The level of isolation provided
+	// for reads is driver specific. Database methods must not be called from within fn.
+	Txn(fn func(tx Tx) error) error
+}
+
+// Txn executes fn within a transaction on the provided Database. If the Database does not implement TxnDatabase,
+// ErrNotSupported is returned and fn is not called.
+func Txn(db Database, fn func(tx Tx) error) error {
+	if db == nil {
+		return ErrInvalidDatabase
+	}
+
+	if tdb, ok := db.(TxnDatabase); ok {
+		return tdb.Txn(fn)
+	}
+
+	return ErrNotSupported
+}
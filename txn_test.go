@@ -0,0 +1,50 @@
+package hord
+
+import (
+	"testing"
+)
+
+// txnDatabase is a mapDatabase that applies transactions without isolation, used to validate Txn dispatch.
+type txnDatabase struct {
+	mapDatabase
+}
+
+func (t *txnDatabase) Txn(fn func(tx Tx) error) error {
+	return fn(&t.mapDatabase)
+}
+
+func TestTxn(t *testing.T) {
+	t.Run("Nil Database", func(t *testing.T) {
+		err := Txn(nil, func(tx Tx) error { return nil })
+		if err != ErrInvalidDatabase {
+			t.Errorf("Expected ErrInvalidDatabase, got %v", err)
+		}
+	})
+
+	t.Run("Not Supported", func(t *testing.T) {
+		called := false
+		err := Txn(&mapDatabase{data: map[string][]byte{}}, func(tx Tx) error {
+			called = true
+			return nil
+		})
+		if err != ErrNotSupported {
+			t.Errorf("Expected ErrNotSupported, got %v", err)
+		}
+		if called {
+			t.Errorf("Expected fn not to be called")
+		}
+	})
+
+	t.Run("Supported", func(t *testing.T) {
+		db := &txnDatabase{mapDatabase{data: map[string][]byte{}}}
+		err := Txn(db, func(tx Tx) error {
+			return tx.Set("a", []byte("1"))
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if string(db.data["a"]) != "1" {
+			t.Errorf("Expected transaction to be applied")
+		}
+	})
+}
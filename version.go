@@ -0,0 +1,42 @@
+package hord
+
+// Version is an opaque revision token returned by GetWithVersion. The contents of a Version are driver specific and
+// should only be passed back to the driver that created it.
+type Version []byte
+
+// VersionedDatabase is an optional interface implemented by drivers that support optimistic concurrency using
+// compare-and-swap operations.
+//
+//	data, version, err := vdb.GetWithVersion("key")
+//	if err != nil {
+//	    // Handle error
+//	}
+//
+//	err = vdb.SetIfVersion("key", modify(data), version)
+//	if errors.Is(err, hord.ErrConflict) {
+//	    // Key was modified since it was read, retry
+//	}
+type VersionedDatabase interface {
+	Database
+
+	// GetWithVersion is used to fetch data and the current Version of the provided key.
+	GetWithVersion(key string) ([]byte, Version, error)
+
+	// SetIfVersion is used to update the specified key only if the key has not been modified since the provided
+	// Version was read. If the key has been modified or deleted, ErrConflict is returned.
+	SetIfVersion(key string, data []byte, version Version) error
+
+	// DeleteIfVersion will delete the specified key only if the key has not been modified since the provided Version
+	// was read. If the key has been modified or deleted, ErrConflict is returned.
+	DeleteIfVersion(key string, version Version) error
+}
+
+// ValidVersion checks if a Version is valid.
+// A valid Version should have a length greater than 0.
+// Returns nil if the Version is valid, otherwise returns ErrInvalidVersion.
+func ValidVersion(version Version) error {
+	if len(version) > 0 {
+		return nil
+	}
+	return ErrInvalidVersion
+}
@@ -0,0 +1,199 @@
+package hord
+
+import (
+	"strings"
+	"sync"
+)
+
+// EventType identifies the kind of change described by an Event.
+type EventType int
+
+const (
+	// EventPut indicates a key was created or updated.
+	EventPut EventType = iota + 1
+
+	// EventDelete indicates a key was deleted or expired.
+	EventDelete
+)
+
+// String returns a human readable name for the EventType.
+func (t EventType) String() string {
+	switch t {
+	case EventPut:
+		return "put"
+	case EventDelete:
+		return "delete"
+	default:
+		return "unknown"
+	}
+}
+
+// Event describes a change to a watched key.
+type Event struct {
+	// Type is the kind of change that occurred.
+	Type EventType
+
+	// Key is the key that changed.
+	Key string
+
+	// Value holds the new data for EventPut events. Value is nil for EventDelete events.
+	Value []byte
+}
+
+// WatchDatabase is an optional interface implemented by drivers that can notify callers of changes to keys.
+//
+//	events, stop := wdb.Watch("config:")
+//	defer stop()
+//
+//	for e := range events {
+//	    switch e.Type {
+//	    case hord.EventPut:
+//	        // Use e.Value
+//	    case hord.EventDelete:
+//	        // Key removed
+//	    }
+//	}
+type WatchDatabase interface {
+	Database
+
+	// Watch returns a channel of Events for the provided key and any keys that start with it, along with a function
+	// used to stop watching. Only changes made after Watch is called are delivered. The channel is closed once stop
+	// is called, the database is closed, or the watch could not be established.
+	Watch(keyOrPrefix string) (<-chan Event, func())
+}
+
+// Broadcaster is an in-process publisher of Events. Drivers that do not have a native change feed can use a
+// Broadcaster to notify watchers of writes made through the same Database instance. The zero value is ready to use.
+//
+// Publish never blocks; events are queued for each watcher and delivered in order as the watcher reads them.
+type Broadcaster struct {
+	// mu protects watchers and closed
+	mu sync.Mutex
+
+	// watchers holds the currently active watchers
+	watchers map[*watcher]struct{}
+
+	// closed is set once Close is called, preventing new watchers
+	closed bool
+}
+
+// Watch registers a new watcher for the provided key or prefix, returning the Event channel and a function used to
+// stop watching. If the Broadcaster is closed, the returned channel is already closed.
+func (b *Broadcaster) Watch(keyOrPrefix string) (<-chan Event, func()) {
+	w := &watcher{
+		prefix: keyOrPrefix,
+		ch:     make(chan Event),
+		notify: make(chan struct{}, 1),
+		done:   make(chan struct{}),
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		close(w.ch)
+		return w.ch, func() {}
+	}
+
+	if b.watchers == nil {
+		b.watchers = make(map[*watcher]struct{})
+	}
+	b.watchers[w] = struct{}{}
+	go w.run()
+
+	return w.ch, func() {
+		b.mu.Lock()
+		delete(b.watchers, w)
+		b.mu.Unlock()
+		w.stop()
+	}
+}
+
+// Publish delivers the Event to every watcher with a matching key or prefix.
+func (b *Broadcaster) Publish(e Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for w := range b.watchers {
+		if strings.HasPrefix(e.Key, w.prefix) {
+			w.push(e)
+		}
+	}
+}
+
+// Close stops all watchers, closing their channels. Watchers registered after Close will receive a closed channel.
+func (b *Broadcaster) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for w := range b.watchers {
+		w.stop()
+	}
+	b.watchers = nil
+	b.closed = true
+}
+
+// watcher queues Events for a single call to Watch, delivering them from a dedicated goroutine so publishers are
+// never blocked by slow readers.
+type watcher struct {
+	sync.Mutex
+
+	// prefix is the key or prefix being watched
+	prefix string
+
+	// ch is the channel Events are delivered on
+	ch chan Event
+
+	// queue holds Events waiting to be delivered
+	queue []Event
+
+	// notify signals run that new Events were queued
+	notify chan struct{}
+
+	// done is closed to stop the watcher
+	done chan struct{}
+
+	// once ensures done is only closed once
+	once sync.Once
+}
+
+// push queues the Event for delivery.
+func (w *watcher) push(e Event) {
+	w.Lock()
+	w.queue = append(w.queue, e)
+	w.Unlock()
+
+	select {
+	case w.notify <- struct{}{}:
+	default:
+	}
+}
+
+// stop signals the watcher to exit and close its channel.
+func (w *watcher) stop() {
+	w.once.Do(func() {
+		close(w.done)
+	})
+}
+
+// run delivers queued Events until the watcher is stopped.
+func (w *watcher) run() {
+	defer close(w.ch)
+	for {
+		w.Lock()
+		queue := w.queue
+		w.queue = nil
+		w.Unlock()
+
+		for _, e := range queue {
+			select {
+			case w.ch <- e:
+			case <-w.done:
+				return
+			}
+		}
+
+		select {
+		case <-w.notify:
+		case <-w.done:
+			return
+		}
+	}
+}
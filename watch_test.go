@@ -0,0 +1,109 @@
+package hord
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBroadcaster(t *testing.T) {
+	t.Run("Prefix Filtering", func(t *testing.T) {
+		var b Broadcaster
+		defer b.Close()
+
+		events, stop := b.Watch("config:")
+		defer stop()
+
+		b.Publish(Event{Type: EventPut, Key: "other", Value: []byte("1")})
+		b.Publish(Event{Type: EventPut, Key: "config:a", Value: []byte("2")})
+		b.Publish(Event{Type: EventDelete, Key: "config:a"})
+
+		for _, want := range []Event{
+			{Type: EventPut, Key: "config:a", Value: []byte("2")},
+			{Type: EventDelete, Key: "config:a"},
+		} {
+			select {
+			case e := <-events:
+				if e.Type != want.Type || e.Key != want.Key || string(e.Value) != string(want.Value) {
+					t.Errorf("Expected event %+v, got %+v", want, e)
+				}
+			case <-time.After(time.Second):
+				t.Fatalf("Timed out waiting for event %+v", want)
+			}
+		}
+	})
+
+	t.Run("Publish Does Not Block", func(t *testing.T) {
+		var b Broadcaster
+		defer b.Close()
+
+		events, stop := b.Watch("")
+		defer stop()
+
+		for i := 0; i < 1000; i++ {
+			b.Publish(Event{Type: EventPut, Key: "key"})
+		}
+
+		for i := 0; i < 1000; i++ {
+			select {
+			case <-events:
+			case <-time.After(time.Second):
+				t.Fatalf("Timed out waiting for event %d", i)
+			}
+		}
+	})
+
+	t.Run("Stop", func(t *testing.T) {
+		var b Broadcaster
+		defer b.Close()
+
+		events, stop := b.Watch("")
+		stop()
+		stop()
+
+		select {
+		case _, ok := <-events:
+			if ok {
+				t.Errorf("Expected channel to be closed after stop")
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Timed out waiting for channel to close")
+		}
+
+		// Publishing after stop should be a no-op
+		b.Publish(Event{Type: EventPut, Key: "key"})
+	})
+
+	t.Run("Close", func(t *testing.T) {
+		var b Broadcaster
+		events, stop := b.Watch("")
+		defer stop()
+		b.Close()
+
+		select {
+		case _, ok := <-events:
+			if ok {
+				t.Errorf("Expected channel to be closed after Close")
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Timed out waiting for channel to close")
+		}
+
+		events, _ = b.Watch("")
+		if _, ok := <-events; ok {
+			t.Errorf("Expected closed channel when watching a closed Broadcaster")
+		}
+	})
+}
+
+func TestEventTypeString(t *testing.T) {
+	tt := map[EventType]string{
+		EventPut:     "put",
+		EventDelete:  "delete",
+		EventType(0): "unknown",
+	}
+	for et, want := range tt {
+		if et.String() != want {
+			t.Errorf("Expected %s, got %s", want, et.String())
+		}
+	}
+}